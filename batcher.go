@@ -0,0 +1,133 @@
+package ringbuffer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//Batcher accumulates items, and flushes them in batches to a callback, for shipping metrics or logs to remote collectors.
+//
+// A batch is flushed as soon as it is full, or when its oldest item has waited for the max delay.
+// When flushes cannot keep up, the oldest items are dropped.
+type Batcher struct {
+	lock      sync.Mutex
+	ring      *Unlocked // of pendings
+	batchSize int
+	maxDelay  time.Duration
+	flush     func(batch []interface{})
+
+	kick   chan struct{}
+	runner Runner
+}
+
+//pending is an item waiting in a batcher, with its arrival time.
+type pending struct {
+	item interface{}
+	at   time.Time
+}
+
+//NewBatcher creates a batcher of 'capacity' pending items, flushing batches of at most 'batchSize' items
+// at most 'maxDelay' after their oldest item was added.
+//
+// 'flush' is called from a single goroutine, the batch is not reused afterwards. Close stops it.
+// A 'batchSize' below 1 is treated as 1. The options configure the pending items ring, WithClock drives the delays.
+func NewBatcher(capacity, batchSize int, maxDelay time.Duration, flush func(batch []interface{}), options ...Option) *Batcher {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	b := &Batcher{
+		ring:      NewUnlocked(capacity, options...),
+		batchSize: batchSize,
+		maxDelay:  maxDelay,
+		flush:     flush,
+		kick:      make(chan struct{}, 1),
+	}
+	b.runner.Start(b.run)
+	return b
+}
+
+//Add queues 'item', dropping the oldest pending item if the batcher is full.
+func (b *Batcher) Add(item interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.ring.put(pending{item: item, at: b.ring.now()})
+	if b.ring.size == 1 || b.ring.size >= b.batchSize {
+		select {
+		case b.kick <- struct{}{}:
+		default:
+		}
+	}
+}
+
+//Dropped returns the number of items dropped because the batcher was full.
+func (b *Batcher) Dropped() uint64 {
+	return b.ring.Dropped()
+}
+
+//Close flushes the pending items, and stops the batcher.
+func (b *Batcher) Close() {
+	b.runner.Stop()
+	b.runner.Wait(context.Background())
+}
+
+//run flushes batches until the batcher is closed.
+func (b *Batcher) run(stop <-chan struct{}) {
+	for {
+		b.lock.Lock()
+		var deadline time.Time // zero: forever
+		if b.ring.size > 0 {
+			deadline = b.ring.buf[b.ring.index(-1)].(pending).at.Add(b.maxDelay) // the oldest item's
+		}
+		if b.ring.size >= b.batchSize || (b.ring.size > 0 && !deadline.After(b.ring.now())) {
+			batch := b.take()
+			b.lock.Unlock()
+			b.flush(batch)
+			continue
+		}
+		b.lock.Unlock()
+		if !b.wait(deadline, stop) {
+			break
+		}
+	}
+	// closed: flush everything left
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for b.ring.size > 0 {
+		batch := b.take()
+		b.lock.Unlock()
+		b.flush(batch)
+		b.lock.Lock()
+	}
+}
+
+//wait waits for an item, the 'deadline' (if not zero) or the batcher to be closed (then it returns false).
+func (b *Batcher) wait(deadline time.Time, stop <-chan struct{}) bool {
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		alarm, release := b.ring.alarm(deadline)
+		defer release()
+		expired = alarm
+	}
+	select {
+	case <-b.kick:
+	case <-expired:
+	case <-stop:
+		return false
+	}
+	return true
+}
+
+//take removes the next batch.
+func (b *Batcher) take() []interface{} {
+	n := b.ring.size
+	if n > b.batchSize {
+		n = b.batchSize
+	}
+	batch := make([]interface{}, n)
+	for i := range batch {
+		p, _ := b.ring.pop()
+		batch[i] = p.(pending).item
+	}
+	return batch
+}
@@ -0,0 +1,98 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatcher(t *testing.T) {
+	var lock sync.Mutex
+	var batches [][]interface{}
+	b := NewBatcher(10, 3, 20*time.Millisecond, func(batch []interface{}) {
+		lock.Lock()
+		defer lock.Unlock()
+		batches = append(batches, batch)
+	})
+	for i := 0; i < 4; i++ {
+		b.Add(i)
+	}
+	time.Sleep(5 * time.Millisecond)
+	lock.Lock()
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("a full batch should be flushed at once, got %v", batches)
+	}
+	lock.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	lock.Lock()
+	if len(batches) != 2 || batches[1][0] != 3 {
+		t.Fatalf("a partial batch should be flushed after the delay, got %v", batches)
+	}
+	lock.Unlock()
+	b.Add(4)
+	b.Close()
+	if len(batches) != 3 || batches[2][0] != 4 {
+		t.Fatalf("Close should flush the pending items, got %v", batches)
+	}
+}
+
+func TestBatcherClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	flushed := make(chan []interface{}, 10)
+	b := NewBatcher(10, 0, time.Second, func(batch []interface{}) { flushed <- batch }, WithClock(clock))
+	defer b.Close()
+	if b.batchSize != 1 {
+		t.Fatalf("a batch size below 1 should be treated as 1, got %v", b.batchSize)
+	}
+
+	b = NewBatcher(10, 3, time.Second, func(batch []interface{}) { flushed <- batch }, WithClock(clock))
+	defer b.Close()
+	b.Add(1)
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case batch := <-flushed:
+		t.Fatalf("a partial batch should not be flushed before the delay, got %v", batch)
+	case <-time.After(10 * time.Millisecond):
+	}
+	clock.Advance(time.Millisecond)
+	select {
+	case batch := <-flushed:
+		if fmt.Sprint(batch) != "[1]" {
+			t.Fatalf("invalid batch %v", batch)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("a partial batch should be flushed once the clock reaches the delay")
+	}
+}
+
+func TestBatcherBacklog(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	release := make(chan struct{})
+	flushed := make(chan []interface{}, 10)
+	b := NewBatcher(10, 2, time.Second, func(batch []interface{}) {
+		flushed <- batch
+		<-release
+	}, WithClock(clock))
+	defer b.Close()
+	defer close(release)
+	b.Add(1)
+	b.Add(2)
+	<-flushed // blocked in the flush, while the backlog grows
+	for i := 3; i <= 5; i++ {
+		b.Add(i)
+	}
+	clock.Advance(time.Second)
+	release <- struct{}{}
+	for _, want := range []string{"[3 4]", "[5]"} {
+		select {
+		case batch := <-flushed:
+			if fmt.Sprint(batch) != want {
+				t.Fatalf("Invalid batch %v, expecting %v", batch, want)
+			}
+			release <- struct{}{}
+		case <-time.After(time.Second):
+			t.Fatalf("The backlog should be flushed at once, as its oldest item has waited the max delay")
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ringbuffer
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+//Bucket aggregates the values recorded during a time interval.
+type Bucket struct {
+	Start         time.Time
+	Width         time.Duration
+	Count         int
+	Sum, Min, Max float64 // Min and Max are NaN for an empty bucket
+}
+
+//End returns the end of the bucket's interval, excluded.
+func (b Bucket) End() time.Time {
+	return b.Start.Add(b.Width)
+}
+
+//Mean returns the mean of the bucket's values, NaN for an empty bucket.
+func (b Bucket) Mean() float64 {
+	if b.Count == 0 {
+		return math.NaN()
+	}
+	return b.Sum / float64(b.Count)
+}
+
+//record aggregates 'v' in the bucket.
+func (b *Bucket) record(v float64) {
+	if b.Count == 0 || v < b.Min {
+		b.Min = v
+	}
+	if b.Count == 0 || v > b.Max {
+		b.Max = v
+	}
+	b.Count++
+	b.Sum += v
+}
+
+//BucketRing aggregates values in time buckets of a fixed width, keeping the last completed ones:
+// a time-window ring, e.g. a value per second over the last minute.
+//
+// Buckets are aligned on their width (see time.Truncate), and roll over lazily,
+// when the ring is used after the end of the current bucket.
+type BucketRing struct {
+	lock     sync.Mutex
+	ring     *Unlocked // the completed buckets
+	current  Bucket
+	width    time.Duration
+	rollover []func(completed Bucket)
+	firing   sync.Mutex // keeps the rollover callbacks in order
+}
+
+//NewBucketRing creates a ring keeping the last 'n' completed buckets of 'width'.
+//
+// The options configure the underlying ring, e.g. WithClock.
+func NewBucketRing(n int, width time.Duration, options ...Option) *BucketRing {
+	b := &BucketRing{ring: NewUnlocked(n, options...), width: width}
+	b.current = b.empty(b.ring.now().Truncate(width))
+	return b
+}
+
+//empty returns an empty bucket starting at 'start'.
+func (b *BucketRing) empty(start time.Time) Bucket {
+	return Bucket{Start: start, Width: b.width, Min: math.NaN(), Max: math.NaN()}
+}
+
+//Record aggregates 'v' in the current bucket.
+func (b *BucketRing) Record(v float64) {
+	b.do(func(now time.Time) { b.current.record(v) })
+}
+
+//Rotate rolls over the buckets that have ended, as every other method does.
+//
+// Call it periodically for the rollover callbacks to fire even when nothing is recorded.
+func (b *BucketRing) Rotate() {
+	b.do(func(now time.Time) {})
+}
+
+//Buckets returns the completed buckets, then the current one, from the oldest to the newest.
+func (b *BucketRing) Buckets() []Bucket {
+	var buckets []Bucket
+	b.do(func(now time.Time) {
+		buckets = make([]Bucket, 0, b.ring.size+1)
+		for _, v := range b.ring.values() {
+			buckets = append(buckets, v.(Bucket))
+		}
+		buckets = append(buckets, b.current)
+	})
+	return buckets
+}
+
+//Current returns the current bucket, not completed yet.
+func (b *BucketRing) Current() Bucket {
+	var current Bucket
+	b.do(func(now time.Time) { current = b.current })
+	return current
+}
+
+//OnRollover registers 'f' to be called with every bucket completed from now on, e.g. to ship per-interval aggregates
+// to a metrics backend.
+//
+// 'f' is called exactly once per interval, including empty ones, from the oldest to the newest,
+// outside the ring's lock. After a long inactivity, only the last buckets that fit in the ring are reported.
+func (b *BucketRing) OnRollover(f func(completed Bucket)) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rollover = append(b.rollover, f)
+}
+
+//do rolls over the ended buckets, then calls 'f' under the lock, and finally fires the rollover callbacks.
+func (b *BucketRing) do(f func(now time.Time)) {
+	b.lock.Lock()
+	now := b.ring.now()
+	completed := b.rotate(now)
+	f(now)
+	callbacks := b.rollover
+	if len(completed) == 0 || len(callbacks) == 0 {
+		b.lock.Unlock()
+		return
+	}
+	b.firing.Lock()
+	b.lock.Unlock()
+	defer b.firing.Unlock()
+	for _, bucket := range completed {
+		for _, callback := range callbacks {
+			callback(bucket)
+		}
+	}
+}
+
+//rotate completes the buckets ended at 'now', and returns them.
+func (b *BucketRing) rotate(now time.Time) (completed []Bucket) {
+	if now.Before(b.current.End()) {
+		return nil
+	}
+	start := now.Truncate(b.width)
+	missed := int(start.Sub(b.current.Start)/b.width) - 1 // empty buckets between the current and the new one
+	completed = append(completed, b.current)
+	missed = min(missed, b.ring.capacity) // the others would not fit anyway
+	for i := missed; i > 0; i-- {
+		completed = append(completed, b.empty(start.Add(-time.Duration(i)*b.width)))
+	}
+	if len(completed) > b.ring.capacity { // only the last ones fit, even the current one may not
+		completed = completed[len(completed)-b.ring.capacity:]
+	}
+	for _, bucket := range completed {
+		b.ring.put(bucket)
+	}
+	b.current = b.empty(start)
+	return completed
+}
@@ -0,0 +1,62 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBucketRing(t *testing.T) {
+	c := NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 500, time.UTC))
+	b := NewBucketRing(3, time.Second, WithClock(c))
+	var completed []string
+	b.OnRollover(func(bucket Bucket) {
+		completed = append(completed, fmt.Sprintf("%d:%d", bucket.Start.Second(), bucket.Count))
+	})
+	b.Record(1)
+	b.Record(3)
+	if cur := b.Current(); cur.Count != 2 || cur.Mean() != 2 || cur.Min != 1 || cur.Max != 3 || cur.Start.Nanosecond() != 0 {
+		t.Fatalf("Invalid current bucket %+v", cur)
+	}
+	c.Advance(time.Second)
+	b.Record(5)
+	c.Advance(2 * time.Second)
+	b.Rotate()
+	if fmt.Sprint(completed) != "[0:2 1:1 2:0]" {
+		t.Fatalf("Invalid rollovers %v", completed)
+	}
+	if buckets := b.Buckets(); len(buckets) != 4 || buckets[1].Sum != 5 || buckets[3].Start.Second() != 3 {
+		t.Fatalf("Invalid buckets %+v", buckets)
+	}
+	completed = nil
+	c.Advance(time.Hour)
+	b.Rotate()
+	if fmt.Sprint(completed) != "[0:0 1:0 2:0]" {
+		t.Fatalf("Only the buckets fitting in the ring should be reported, got %v", completed)
+	}
+	if buckets := b.Buckets(); fmt.Sprint(len(buckets), buckets[0].Start.Second(), buckets[3].Start.Second()) != "4 0 3" {
+		t.Fatalf("The ring should keep the last completed buckets, got %+v", buckets)
+	}
+}
+
+func TestBucketRingLongGap(t *testing.T) {
+	c := NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := NewBucketRing(3, time.Second, WithClock(c))
+	var completed []string
+	b.OnRollover(func(bucket Bucket) {
+		completed = append(completed, fmt.Sprintf("%d:%d", bucket.Start.Second(), bucket.Count))
+	})
+	b.Record(1)
+	c.Advance(3 * time.Second)
+	b.Rotate()
+	if fmt.Sprint(completed) != "[0:1 1:0 2:0]" {
+		t.Fatalf("A gap of the ring's capacity should report every bucket, got %v", completed)
+	}
+	completed = nil
+	b.Record(1)
+	c.Advance(4 * time.Second)
+	b.Rotate()
+	if fmt.Sprint(completed) != "[4:0 5:0 6:0]" {
+		t.Fatalf("A longer gap should report the ring's capacity of buckets, got %v", completed)
+	}
+}
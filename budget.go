@@ -0,0 +1,66 @@
+package ringbuffer
+
+//WithBudget expresses the ring's capacity as a memory budget too.
+//
+// 'sizeOf' estimates the size in bytes of a value. It must always return the same estimate for
+// a given value, as it is called again when the value leaves the ring.
+//
+// In budget mode, Add fails with ErrFull when either the capacity or the budget is exhausted,
+// and Push removes as many oldest values as needed to fit the new one.
+func WithBudget(budget int, sizeOf func(v interface{}) int) Option {
+	return func(b *ring) {
+		b.budget = budget
+		b.sizeOf = sizeOf
+	}
+}
+
+//Budget returns the ring's memory budget in bytes, or 0 if it is not in budget mode.
+func (b *Ring) Budget() int {
+	return b.budget
+}
+
+//Bytes returns the estimated size of the ring's values, or 0 if it is not in budget mode.
+func (b *Ring) Bytes() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.bytes
+}
+
+//bytesOf estimates the size of 'values'.
+func (b *ring) bytesOf(values []interface{}) (n int) {
+	if b.sizeOf == nil {
+		return 0
+	}
+	for _, v := range values {
+		n += b.sizeOf(v)
+	}
+	return n
+}
+
+//pushBudget adds 'value' to the head, after removing as many oldest values as needed to fit it.
+//
+// A value bigger than the whole budget is discarded, leaving the ring untouched.
+func (b *ring) pushBudget(value interface{}) {
+	bytes := b.sizeOf(value)
+	if bytes > b.budget || b.capacity == 0 {
+		b.stats.dropped.Add(1)
+		b.seq-- // the value never entered the ring: the head keeps its sequence number
+		if b.fold != nil {
+			b.fold(value)
+		}
+		return
+	}
+	for b.size > 0 && (b.bytes+bytes > b.budget || b.size == b.capacity) {
+		if b.fold != nil {
+			b.fold(b.buf[b.index(-1)])
+		}
+		b.evict(1)
+		b.stats.dropped.Add(1)
+	}
+	b.grow(b.size + 1)
+	next := b.next(1)
+	b.buf[next] = value
+	b.head = next
+	b.size++
+	b.bytes += bytes
+}
@@ -0,0 +1,49 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleWithBudget() {
+	buf := New(10, WithBudget(10, func(v interface{}) int { return len(v.(string)) }))
+	buf.Push("hello", "big", "world") //"hello" is removed to fit "world"
+	fmt.Println(buf.Size(), buf.Bytes())
+	//Output: 2 8
+}
+
+func TestBudget(t *testing.T) {
+	b := New(4, WithBudget(10, func(v interface{}) int { return v.(int) }))
+
+	if err := b.Add(3, 4); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := b.Add(4); !errors.Is(err, ErrFull) {
+		t.Fatalf("should have failed with ErrFull, got %v", err)
+	}
+	if b.Bytes() != 7 {
+		t.Fatalf("Invalid bytes %v, expecting %v", b.Bytes(), 7)
+	}
+
+	//pushing 6 must evict 3 only
+	b.Push(6)
+	if b.Size() != 2 || b.Bytes() != 10 {
+		t.Fatalf("Invalid size/bytes %v/%v, expecting %v/%v", b.Size(), b.Bytes(), 2, 10)
+	}
+	//the capacity still applies
+	b.Push(1, 1, 1, 1)
+	if b.Size() != 4 || b.Bytes() != 4 {
+		t.Fatalf("Invalid size/bytes %v/%v, expecting %v/%v", b.Size(), b.Bytes(), 4, 4)
+	}
+	//too big values are discarded
+	b.Push(11)
+	if b.Size() != 4 || b.Bytes() != 4 {
+		t.Fatalf("Invalid size/bytes %v/%v, expecting %v/%v", b.Size(), b.Bytes(), 4, 4)
+	}
+
+	b.Remove(3)
+	if b.Bytes() != 1 {
+		t.Fatalf("Invalid bytes %v, expecting %v", b.Bytes(), 1)
+	}
+}
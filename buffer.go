@@ -0,0 +1,18 @@
+package ringbuffer
+
+//Buffer is the essence of a ring buffer, implemented by Ring and Unlocked.
+//
+// Application code can depend on it, and tests substitute instrumented or fake implementations.
+type Buffer interface {
+	Add(values ...interface{}) error
+	Push(values ...interface{})
+	Get(i int) (interface{}, error)
+	Remove(count int)
+	Size() int
+	Capacity() int
+}
+
+var (
+	_ Buffer = (*Ring)(nil)
+	_ Buffer = (*Unlocked)(nil)
+)
@@ -0,0 +1,30 @@
+package ringbuffer
+
+import "testing"
+
+//counting decorates a Buffer, counting the values added.
+type counting struct {
+	Buffer
+	added int
+}
+
+func (c *counting) Add(values ...interface{}) error {
+	err := c.Buffer.Add(values...)
+	if err == nil {
+		c.added += len(values)
+	}
+	return err
+}
+
+func TestBuffer(t *testing.T) {
+	for _, b := range []Buffer{New(3), NewUnlocked(3)} {
+		c := &counting{Buffer: b}
+		c.Add(1, 2)
+		c.Add(3, 4)
+		c.Push(5)
+		c.Remove(1)
+		if v, _ := c.Get(0); v != 5 || c.Size() != 1 || c.Capacity() != 3 || c.added != 2 {
+			t.Fatalf("Invalid %T: newest %v, size %v, added %v", b, v, c.Size(), c.added)
+		}
+	}
+}
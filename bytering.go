@@ -0,0 +1,136 @@
+package ringbuffer
+
+import (
+	"io"
+	"sync"
+)
+
+//ByteRing is a ring buffer of bytes.
+//
+// Writes never fail: they discard the oldest bytes when the ring is full. Reads consume the oldest bytes.
+// It is safe for concurrent use.
+type ByteRing struct {
+	lock    sync.Mutex
+	buf     []byte
+	layout  Layout
+	dropped uint64
+
+	last     int // absolute position of the last byte consumed
+	unread   int // number of bytes that can be unread before 'last'
+	runeRead bool
+}
+
+//NewByteRing creates a new, empty ring of 'capacity' bytes.
+func NewByteRing(capacity int) *ByteRing {
+	return &ByteRing{buf: make([]byte, capacity), layout: NewLayout(capacity)}
+}
+
+//Write adds 'p' to the ring, discarding the oldest bytes to make room for it.
+//
+// If 'p' is bigger than the ring's capacity only its last bytes are kept. It always returns len(p), nil.
+func (b *ByteRing) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.write(p)
+	return len(p), nil
+}
+
+//Read consumes the oldest bytes into 'p'. It returns io.EOF if the ring is empty.
+func (b *ByteRing) Read(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.layout.Size == 0 {
+		b.consume(0, 0)
+		return 0, io.EOF
+	}
+	n := b.peek(p)
+	b.consume(n, 1)
+	return n, nil
+}
+
+//Peek returns a copy of the 'n' oldest bytes, without consuming them.
+//
+// It returns fewer bytes, and io.EOF, if the ring holds less than 'n' bytes.
+func (b *ByteRing) Peek(n int) ([]byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var err error
+	if n > b.layout.Size {
+		n, err = b.layout.Size, io.EOF
+	}
+	p := make([]byte, n)
+	b.peek(p)
+	return p, err
+}
+
+//Bytes returns a copy of the ring's bytes, from the oldest to the newest.
+func (b *ByteRing) Bytes() []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	p := make([]byte, b.layout.Size)
+	b.peek(p)
+	return p
+}
+
+//Len returns the number of bytes in the ring.
+func (b *ByteRing) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.layout.Size
+}
+
+//Cap returns the ring's capacity.
+func (b *ByteRing) Cap() int {
+	return len(b.buf)
+}
+
+//Dropped returns the number of bytes discarded by Write since the ring's creation.
+func (b *ByteRing) Dropped() uint64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.dropped
+}
+
+//Reset discards all the bytes.
+func (b *ByteRing) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.layout = b.layout.Remove(b.layout.Size)
+	b.unread = 0
+}
+
+//write adds 'p' at the head, discarding the oldest bytes if needed.
+func (b *ByteRing) write(p []byte) {
+	b.unread = 0 // consumed bytes may be overwritten
+	capacity := len(b.buf)
+	if len(p) > capacity {
+		b.dropped += uint64(len(p) - capacity)
+		p = p[len(p)-capacity:]
+	}
+	if len(p) == 0 {
+		return
+	}
+	if over := b.layout.Size + len(p) - capacity; over > 0 {
+		b.dropped += uint64(over)
+		b.layout = b.layout.Remove(over)
+	}
+	first, second := b.layout.Ranges(b.layout.Next(1), len(p))
+	n := copy(b.buf[first[0]:first[1]], p)
+	copy(b.buf[second[0]:second[1]], p[n:])
+	b.layout = b.layout.Add(len(p))
+}
+
+//peek copies the oldest bytes into 'p', and returns the number of bytes copied.
+func (b *ByteRing) peek(p []byte) int {
+	n := len(p)
+	if n > b.layout.Size {
+		n = b.layout.Size
+	}
+	if n == 0 {
+		return 0
+	}
+	first, second := b.layout.Ranges(b.layout.Tail(), n)
+	c := copy(p, b.buf[first[0]:first[1]])
+	copy(p[c:], b.buf[second[0]:second[1]])
+	return n
+}
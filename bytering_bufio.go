@@ -0,0 +1,86 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+//ReadByte consumes the oldest byte. It returns io.EOF if the ring is empty.
+func (b *ByteRing) ReadByte() (byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.layout.Size == 0 {
+		b.consume(0, 0)
+		return 0, io.EOF
+	}
+	c := b.buf[b.layout.Tail()]
+	b.consume(1, 1)
+	return c, nil
+}
+
+//UnreadByte restores the last byte consumed.
+//
+// It fails with bufio.ErrInvalidUnreadByte if there was no read since the last write.
+func (b *ByteRing) UnreadByte() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.unread == 0 {
+		return bufio.ErrInvalidUnreadByte
+	}
+	b.restore(1)
+	return nil
+}
+
+//ReadRune consumes the oldest UTF-8 encoded rune, and returns it with its size in bytes.
+//
+// Invalid or incomplete encodings are consumed one byte at a time as utf8.RuneError. It returns io.EOF if the ring is empty.
+func (b *ByteRing) ReadRune() (r rune, size int, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.layout.Size == 0 {
+		b.consume(0, 0)
+		return 0, 0, io.EOF
+	}
+	var p [utf8.UTFMax]byte
+	n := b.peek(p[:])
+	r, size = utf8.DecodeRune(p[:n])
+	b.consume(size, size)
+	b.runeRead = true
+	return r, size, nil
+}
+
+//UnreadRune restores the last rune consumed.
+//
+// It fails with bufio.ErrInvalidUnreadRune if the last read was not a ReadRune.
+func (b *ByteRing) UnreadRune() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.runeRead || b.unread == 0 {
+		return bufio.ErrInvalidUnreadRune
+	}
+	b.restore(b.unread)
+	return nil
+}
+
+//consume removes the 'n' oldest bytes, the last 'unread' of them being restorable.
+func (b *ByteRing) consume(n, unread int) {
+	b.runeRead = false
+	if n == 0 {
+		b.unread = 0
+		return
+	}
+	b.last = b.layout.Index(-n)
+	b.unread = unread
+	b.layout = b.layout.Remove(n)
+}
+
+//restore puts back the 'n' last bytes consumed.
+func (b *ByteRing) restore(n int) {
+	if b.layout.Size == 0 {
+		b.layout.Head = b.last
+	}
+	b.layout.Size += n
+	b.unread = 0
+	b.runeRead = false
+}
@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"io"
+	"testing"
+	"text/scanner"
+)
+
+func TestByteRingRunes(t *testing.T) {
+	b := NewByteRing(8)
+	b.Write([]byte("hé!"))
+	c, _ := b.ReadByte()
+	if c != 'h' {
+		t.Fatalf("Invalid byte %q", c)
+	}
+	if err := b.UnreadByte(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := b.UnreadByte(); err != bufio.ErrInvalidUnreadByte {
+		t.Fatalf("should have failed with ErrInvalidUnreadByte, got %v", err)
+	}
+	b.ReadByte()
+	r, size, _ := b.ReadRune()
+	if r != 'é' || size != 2 {
+		t.Fatalf("Invalid rune %q (%v)", r, size)
+	}
+	b.ReadRune()
+	if b.Len() != 0 {
+		t.Fatalf("Invalid length %v", b.Len())
+	}
+	if err := b.UnreadRune(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r, _, _ := b.ReadRune(); r != '!' {
+		t.Fatalf("Invalid rune %q", r)
+	}
+	if _, _, err := b.ReadRune(); err != io.EOF {
+		t.Fatalf("should have failed with EOF, got %v", err)
+	}
+	b.ReadByte()
+	if err := b.UnreadRune(); err != bufio.ErrInvalidUnreadRune {
+		t.Fatalf("should have failed with ErrInvalidUnreadRune, got %v", err)
+	}
+
+	// a tokenizer can run directly over the ring
+	var _ io.RuneScanner = b
+	var s scanner.Scanner
+	b.Write([]byte("x := 42"))
+	s.Init(b)
+	var tokens []string
+	for tok := s.Scan(); tok != scanner.EOF; tok = s.Scan() {
+		tokens = append(tokens, s.TokenText())
+	}
+	if len(tokens) != 4 || tokens[3] != "42" {
+		t.Fatalf("Invalid tokens %q", tokens)
+	}
+}
@@ -0,0 +1,34 @@
+package ringbuffer
+
+import (
+	"io"
+	"testing"
+)
+
+func TestByteRing(t *testing.T) {
+	b := NewByteRing(5)
+	b.Write([]byte("abc"))
+	b.Write([]byte("defg")) // discards "ab"
+	if string(b.Bytes()) != "cdefg" || b.Dropped() != 2 {
+		t.Fatalf("Invalid bytes %q (dropped %v)", b.Bytes(), b.Dropped())
+	}
+	p, _ := b.Peek(2)
+	if string(p) != "cd" {
+		t.Fatalf("Invalid peek %q", p)
+	}
+	p = make([]byte, 3)
+	if n, _ := b.Read(p); n != 3 || string(p) != "cde" {
+		t.Fatalf("Invalid read %q", p[:n])
+	}
+	b.Write([]byte("0123456789"))
+	if string(b.Bytes()) != "56789" || b.Len() != 5 {
+		t.Fatalf("Invalid bytes %q", b.Bytes())
+	}
+	all, _ := io.ReadAll(b)
+	if string(all) != "56789" || b.Len() != 0 {
+		t.Fatalf("Invalid read %q", all)
+	}
+	if _, err := b.Peek(1); err != io.EOF {
+		t.Fatalf("should have failed with EOF, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package ringbuffer
+
+import "iter"
+
+//Chunks yields the ring's values in successive batches of up to 'n' values, from the oldest to the newest.
+//
+// Each batch is a new slice, filled with at most two copies. The ring is only locked while a batch is copied:
+// values pushed out of the ring meanwhile are skipped, values added meanwhile are yielded too.
+func (b *Ring) Chunks(n int) iter.Seq[[]interface{}] {
+	return func(yield func([]interface{}) bool) {
+		if n < 1 {
+			return
+		}
+		b.lock.RLock()
+		next := b.seq - uint64(b.size) + 1 // sequence number of the next value to yield
+		b.lock.RUnlock()
+		for {
+			b.lock.RLock()
+			chunk, seq := b.chunk(next, n)
+			b.lock.RUnlock()
+			if len(chunk) == 0 || !yield(chunk) {
+				return
+			}
+			next = seq + uint64(len(chunk))
+		}
+	}
+}
+
+//chunk returns up to 'n' values from the sequence number 'from' (or the oldest value, if it has left the ring),
+// and the sequence number of the first one.
+func (b *ring) chunk(from uint64, n int) ([]interface{}, uint64) {
+	if oldest := b.seq - uint64(b.size) + 1; from < oldest {
+		from = oldest
+	}
+	if from > b.seq {
+		return nil, from
+	}
+	i := int(b.seq - from) // index of the first value
+	if n > i+1 {
+		n = i + 1
+	}
+	values := make([]interface{}, n)
+	first, second := b.span(b.index(i), n)
+	c := copy(values, first)
+	copy(values[c:], second)
+	return values, from
+}
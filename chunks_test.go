@@ -0,0 +1,36 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestChunks(t *testing.T) {
+	b := New(5)
+	b.Add(1, 2, 3)
+	b.Push(4, 5) // wraps around
+	var chunks [][]interface{}
+	for chunk := range b.Chunks(2) {
+		chunks = append(chunks, chunk)
+	}
+	if fmt.Sprint(chunks) != "[[3 4] [5]]" {
+		t.Fatalf("Invalid chunks %v", chunks)
+	}
+
+	b = New(10)
+	b.Add(1, 2, 3, 4, 5)
+	chunks = nil
+	for chunk := range b.Chunks(2) {
+		chunks = append(chunks, chunk)
+		if len(chunks) == 1 {
+			b.Remove(3) // 3 is skipped
+			b.Add(6)
+		}
+	}
+	if fmt.Sprint(chunks) != "[[1 2] [4 5] [6]]" {
+		t.Fatalf("Invalid chunks under modifications %v", chunks)
+	}
+	for range New(3).Chunks(2) {
+		t.Fatalf("An empty ring has no chunk")
+	}
+}
@@ -0,0 +1,142 @@
+package ringbuffer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//Clock tells the time to the ring's time-based features (see WithClock).
+type Clock interface {
+	Now() time.Time
+}
+
+//ClockFunc adapts a function to a Clock.
+type ClockFunc func() time.Time
+
+//Now calls f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+//SystemClock is the default Clock, that is time.Now.
+var SystemClock Clock = ClockFunc(time.Now)
+
+//WithClock makes the ring's time-based features (like WithRelaxedReads) use 'c' instead of the SystemClock.
+//
+// Tests and simulations can then drive them deterministically, see ManualClock.
+func WithClock(c Clock) Option {
+	return func(b *ring) {
+		b.clock = c
+	}
+}
+
+//now returns the time according to the ring's clock.
+func (b *ring) now() time.Time {
+	if b.clock == nil {
+		return time.Now()
+	}
+	return b.clock.Now()
+}
+
+//AlarmClock is a Clock that also rings alarms: the features that wait for a delay (like Batcher) use it
+// instead of a system timer when the clock implements it.
+type AlarmClock interface {
+	Clock
+	//Alarm returns a channel that receives the time once the clock has reached 'at'.
+	Alarm(at time.Time) <-chan time.Time
+}
+
+//alarm returns a channel that receives the time once the ring's clock has reached 'at', and a function to release it.
+func (b *ring) alarm(at time.Time) (<-chan time.Time, func()) {
+	if c, ok := b.clock.(AlarmClock); ok {
+		return c.Alarm(at), func() {}
+	}
+	timer := time.NewTimer(at.Sub(b.now()))
+	return timer.C, func() { timer.Stop() }
+}
+
+//withTimeout returns a copy of 'ctx' cancelled once the ring's clock has advanced by 'd' (see context.WithTimeout).
+func (b *ring) withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := b.clock.(AlarmClock); !ok {
+		return context.WithTimeout(ctx, d)
+	}
+	alarm, release := b.alarm(b.now().Add(d))
+	wait, cancel := context.WithCancel(ctx)
+	go func() {
+		defer release()
+		select {
+		case <-alarm:
+			cancel()
+		case <-wait.Done():
+		}
+	}()
+	return wait, cancel
+}
+
+//ManualClock is a Clock that only moves when told to.
+//
+// It is safe for concurrent use.
+type ManualClock struct {
+	lock   sync.Mutex
+	t      time.Time
+	alarms []manualAlarm
+}
+
+//manualAlarm is an alarm waiting for the ManualClock to reach 'at'.
+type manualAlarm struct {
+	at time.Time
+	c  chan time.Time
+}
+
+//NewManualClock creates a clock stopped at 't'.
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{t: t}
+}
+
+//Now returns the clock's time.
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.t
+}
+
+//Set moves the clock to 't'.
+func (c *ManualClock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.t = t
+	c.ring()
+}
+
+//Advance moves the clock forward by 'd', and returns the new time.
+func (c *ManualClock) Advance(d time.Duration) time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.t = c.t.Add(d)
+	c.ring()
+	return c.t
+}
+
+//Alarm returns a channel that receives the time once the clock has been moved to 'at' or later.
+func (c *ManualClock) Alarm(at time.Time) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	a := manualAlarm{at: at, c: make(chan time.Time, 1)}
+	c.alarms = append(c.alarms, a)
+	c.ring()
+	return a.c
+}
+
+//ring fires the alarms that are due.
+func (c *ManualClock) ring() {
+	pending := c.alarms[:0]
+	for _, a := range c.alarms {
+		if a.at.After(c.t) {
+			pending = append(pending, a)
+			continue
+		}
+		a.c <- c.t
+	}
+	c.alarms = pending
+}
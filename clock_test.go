@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewManualClock(start)
+	b := New(3, WithClock(c), WithRelaxedReads(time.Second))
+	b.Add(1)
+	b.Get(0)
+	b.Add(2)
+	c.Advance(999 * time.Millisecond)
+	if v, _ := b.Get(0); v != 1 {
+		t.Fatalf("Get(0) should return the stale %v, got %v", 1, v)
+	}
+	if now := c.Advance(time.Millisecond); !now.Equal(start.Add(time.Second)) {
+		t.Fatalf("Invalid time %v", now)
+	}
+	if v, _ := b.Get(0); v != 2 {
+		t.Fatalf("Get(0) should return the refreshed %v, got %v", 2, v)
+	}
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Fatalf("Invalid time %v", c.Now())
+	}
+	if New(1).now().IsZero() {
+		t.Fatalf("The default clock should be the system clock")
+	}
+}
+
+func TestManualClockAlarm(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewManualClock(start)
+	alarm := c.Alarm(start.Add(time.Second))
+	c.Advance(999 * time.Millisecond)
+	select {
+	case <-alarm:
+		t.Fatalf("The alarm should not ring before its time")
+	default:
+	}
+	c.Advance(time.Millisecond)
+	select {
+	case now := <-alarm:
+		if !now.Equal(start.Add(time.Second)) {
+			t.Fatalf("Invalid time %v", now)
+		}
+	default:
+		t.Fatalf("The alarm should ring once the clock reaches its time")
+	}
+	select {
+	case <-c.Alarm(start):
+	default:
+		t.Fatalf("An alarm in the past should ring at once")
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2014 @ericaro. All rights reserved.
+// Use of this source code is governed by a Apache License, Version 2.0.
+
+// Command ringbuf inspects persisted ring files, of any version (see Ring.SaveFile), and mapped ring files (see OpenMapped).
+//
+// Usage:
+//
+//	ringbuf info FILE
+//	ringbuf dump FILE
+//	ringbuf tail [-n 10] FILE
+//	ringbuf export [-format=json|csv] FILE
+//
+// Elements are printed as text when they are valid UTF-8, and in hexadecimal otherwise.
+// Encrypted files (see ringbuffer.SaveFileEncrypted) are read with -key, the AES key in hexadecimal.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/ericaro/ringbuffer"
+)
+
+//errUsage is returned by run when the command line is invalid.
+var errUsage = errors.New("invalid command line")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err == errUsage {
+		usage()
+	} else if err != nil {
+		fatal(err)
+	}
+}
+
+//run executes the command line 'args' (without the program name), printing to 'w'.
+func run(args []string, w io.Writer) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+	cmd, args := args[0], args[1:]
+	flags := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	n := flags.Int("n", 10, "number of elements to print (tail)")
+	format := flags.String("format", "json", "export format: json or csv (export)")
+	key := flags.String("key", "", "AES key in hexadecimal, to read encrypted files")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 || *n < 0 {
+		return errUsage
+	}
+	f, err := open(flags.Arg(0), *key)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "info":
+		_, err = fmt.Fprintf(w, "capacity: %d\nsize: %d\nhead sequence: %d\n", f.capacity, len(f.values), f.sequence)
+	case "dump":
+		err = dump(w, f.values)
+	case "tail":
+		values := f.values
+		if *n < len(values) {
+			values = values[len(values)-*n:]
+		}
+		err = dump(w, values)
+	case "export":
+		err = export(w, f, *format)
+	default:
+		return errUsage
+	}
+	return err
+}
+
+//file is the content of a ring file.
+type file struct {
+	capacity int
+	sequence uint64
+	values   []interface{} // from the oldest to the newest
+}
+
+//open reads a ring file, decrypted with 'key' if any, keeping its elements as bytes.
+//
+// Mapped ring files (see ringbuffer.OpenMapped) are read without disturbing their writer.
+func open(path, key string) (*file, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	header := make([]byte, 4)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	if key == "" && ringbuffer.IsMappedFile(header[:n]) {
+		return openMapped(path)
+	}
+	data, err := io.ReadAll(io.MultiReader(bytes.NewReader(header[:n]), f))
+	if err != nil {
+		return nil, err
+	}
+	b, err := unmarshal(data, key)
+	if err != nil {
+		return nil, err
+	}
+	return &file{capacity: b.Capacity(), sequence: b.Sequence(), values: b.Values()}, nil
+}
+
+//unmarshal decodes a ring file, decrypted with 'key' if any, keeping its elements as bytes.
+func unmarshal(data []byte, key string) (*ringbuffer.Ring, error) {
+	decode := func(p []byte) (interface{}, error) { return p, nil }
+	if key == "" {
+		return ringbuffer.UnmarshalFile(data, decode)
+	}
+	k, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %v", err)
+	}
+	return ringbuffer.UnmarshalFileEncrypted(data, k, decode)
+}
+
+//openMapped reads a mapped ring file.
+func openMapped(path string) (*file, error) {
+	r, err := ringbuffer.OpenReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	f := &file{capacity: r.Capacity(), sequence: r.Sequence()}
+	for _, p := range r.Values() {
+		f.values = append(f.values, append([]byte(nil), p...))
+	}
+	return f, nil
+}
+
+//dump prints 'values', one per line.
+func dump(w io.Writer, values []interface{}) error {
+	for _, v := range values {
+		if _, err := fmt.Fprintln(w, text(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//export prints the ring in 'format'.
+func export(w io.Writer, f *file, format string) error {
+	values := f.values
+	first := f.sequence - uint64(len(values)) + 1
+	switch format {
+	case "json":
+		elements := make([]string, len(values))
+		for i, v := range values {
+			elements[i] = text(v)
+		}
+		return json.NewEncoder(w).Encode(struct {
+			Capacity     int      `json:"capacity"`
+			HeadSequence uint64   `json:"head_sequence"`
+			Elements     []string `json:"elements"`
+		}{f.capacity, f.sequence, elements})
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"sequence", "element"})
+		for i, v := range values {
+			cw.Write([]string{strconv.FormatUint(first+uint64(i), 10), text(v)})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+//text returns an element as text, or in hexadecimal.
+func text(v interface{}) string {
+	p := v.([]byte)
+	if utf8.Valid(p) {
+		return string(p)
+	}
+	return hex.EncodeToString(p)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ringbuf info|dump|tail|export [-n 10] [-format=json|csv] [-key HEX] FILE")
+	os.Exit(2)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "ringbuf:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ericaro/ringbuffer"
+)
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	b := ringbuffer.New(3)
+	b.Add("a", "b", "c")
+	b.Remove(1)
+	b.Add("d")
+	if err := b.SaveFile(path, func(v interface{}) ([]byte, error) { return []byte(v.(string)), nil }); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+	for _, c := range []struct {
+		args []string
+		out  string
+	}{
+		{[]string{"info", path}, "capacity: 3\nsize: 3\nhead sequence: 4\n"},
+		{[]string{"dump", path}, "b\nc\nd\n"},
+		{[]string{"tail", "-n", "1", path}, "d\n"},
+		{[]string{"export", "-format=csv", path}, "sequence,element\n2,b\n3,c\n4,d\n"},
+		{[]string{"export", path}, `{"capacity":3,"head_sequence":4,"elements":["b","c","d"]}` + "\n"},
+	} {
+		var out bytes.Buffer
+		if err := run(c.args, &out); err != nil || out.String() != c.out {
+			t.Fatalf("%s should print %q, got %q, %v", strings.Join(c.args, " "), c.out, out.String(), err)
+		}
+	}
+	for _, args := range [][]string{nil, {"info"}, {"unknown", path}, {"info", "-x", path}, {"tail", "-n", "-1", path}} {
+		if err := run(args, new(bytes.Buffer)); err != errUsage {
+			t.Fatalf("%q should be rejected, got %v", args, err)
+		}
+	}
+	encrypted := path + ".enc"
+	if err := b.SaveFileEncrypted(encrypted, func(v interface{}) ([]byte, error) { return []byte(v.(string)), nil }, bytes.Repeat([]byte{7}, 16)); err != nil {
+		t.Fatalf("SaveFileEncrypted failed: %v", err)
+	}
+	var out bytes.Buffer
+	if err := run([]string{"dump", "-key", strings.Repeat("07", 16), encrypted}, &out); err != nil || out.String() != "b\nc\nd\n" {
+		t.Fatalf("dump should decrypt the file, got %q, %v", out.String(), err)
+	}
+	if err := run([]string{"dump", encrypted}, new(bytes.Buffer)); !errors.Is(err, ringbuffer.ErrEncrypted) {
+		t.Fatalf("An encrypted file should require the key, got %v", err)
+	}
+	if err := run([]string{"info", path + ".missing"}, new(bytes.Buffer)); err == nil {
+		t.Fatalf("A missing file should fail")
+	}
+}
+
+func TestRunMapped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	r, err := ringbuffer.OpenMapped(path, 3, 4)
+	if err != nil {
+		t.Fatalf("OpenMapped failed: %v", err)
+	}
+	defer r.Close()
+	r.Push([]byte("a"))
+	r.Push([]byte{0xff})
+	var out bytes.Buffer
+	if err := run([]string{"info", path}, &out); err != nil || out.String() != "capacity: 3\nsize: 2\nhead sequence: 2\n" {
+		t.Fatalf("info should read the mapped file, got %q, %v", out.String(), err)
+	}
+	out.Reset()
+	if err := run([]string{"dump", path}, &out); err != nil || out.String() != "a\nff\n" {
+		t.Fatalf("dump should read the mapped file, got %q, %v", out.String(), err)
+	}
+}
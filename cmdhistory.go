@@ -0,0 +1,77 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+//CmdHistory is a readline-style command history, for REPL and shell-like tools.
+//
+// Consecutive duplicates and empty commands are not recorded.
+type CmdHistory struct {
+	ring *Ring
+}
+
+//NewCmdHistory creates a history keeping the last 'capacity' commands.
+func NewCmdHistory(capacity int) *CmdHistory {
+	return &CmdHistory{ring: New(capacity)}
+}
+
+//Add records 'cmd', discarding the oldest command if the history is full.
+//
+// Its trailing newline is removed, and it is ignored if empty or equal to the last command.
+func (h *CmdHistory) Add(cmd string) {
+	cmd = strings.TrimRight(cmd, "\r\n")
+	if strings.TrimSpace(cmd) == "" {
+		return
+	}
+	h.ring.lock.Lock()
+	defer h.ring.unlock()
+	if last, err := h.ring.get(0); err == nil && last == cmd {
+		return
+	}
+	h.ring.put(cmd)
+}
+
+//Commands returns the recorded commands, from the oldest to the newest.
+func (h *CmdHistory) Commands() []string {
+	values := h.ring.Values()
+	cmds := make([]string, len(values))
+	for i, v := range values {
+		cmds[i] = v.(string)
+	}
+	return cmds
+}
+
+//SearchPrefix returns the commands starting with 'prefix', from the newest to the oldest.
+func (h *CmdHistory) SearchPrefix(prefix string) []string {
+	h.ring.lock.RLock()
+	defer h.ring.lock.RUnlock()
+	var cmds []string
+	for i := 0; i < h.ring.size; i++ {
+		if cmd := h.ring.buf[h.ring.index(i)].(string); strings.HasPrefix(cmd, prefix) {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+//Save writes the commands to 'w', one per line, from the oldest to the newest.
+func (h *CmdHistory) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, cmd := range h.Commands() {
+		bw.WriteString(cmd)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+//Load adds the commands read from 'r', one per line (see Save).
+func (h *CmdHistory) Load(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		h.Add(s.Text())
+	}
+	return s.Err()
+}
@@ -0,0 +1,31 @@
+package ringbuffer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCmdHistory(t *testing.T) {
+	h := NewCmdHistory(4)
+	for _, cmd := range []string{"ls", "git status", "git status", "", "cd /tmp\n", "git commit"} {
+		h.Add(cmd)
+	}
+	if cmds := strings.Join(h.Commands(), ","); cmds != "ls,git status,cd /tmp,git commit" {
+		t.Fatalf("Invalid commands %q", cmds)
+	}
+	if cmds := strings.Join(h.SearchPrefix("git "), ","); cmds != "git commit,git status" {
+		t.Fatalf("Invalid search %q", cmds)
+	}
+
+	var saved strings.Builder
+	if err := h.Save(&saved); err != nil {
+		t.Fatal(err.Error())
+	}
+	c := NewCmdHistory(2)
+	if err := c.Load(strings.NewReader(saved.String())); err != nil {
+		t.Fatal(err.Error())
+	}
+	if cmds := strings.Join(c.Commands(), ","); cmds != "cd /tmp,git commit" {
+		t.Fatalf("Invalid loaded commands %q", cmds)
+	}
+}
@@ -0,0 +1,50 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ring files are portable: the snapshot format only uses varints and length-prefixed bytes,
+// which do not depend on the architecture. Elements are encoded by the caller:
+// the codecs below encode numbers in little-endian fixed-width fields, whatever the architecture.
+
+//EncodeFloat64 encodes a numeric value (see Sample) as a little-endian IEEE 754 float64, for MarshalSnapshot.
+func EncodeFloat64(v interface{}) ([]byte, error) {
+	x, ok := number(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotNumeric, v)
+	}
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(x)), nil
+}
+
+//DecodeFloat64 decodes a float64 encoded by EncodeFloat64, for UnmarshalSnapshot.
+func DecodeFloat64(p []byte) (interface{}, error) {
+	if len(p) != 8 {
+		return nil, fmt.Errorf("%w: float64 of %d bytes", ErrSnapshot, len(p))
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(p)), nil
+}
+
+//EncodeSample encodes a Sample as its time in Unix nanoseconds (int64) and its value (float64), both little-endian.
+func EncodeSample(v interface{}) ([]byte, error) {
+	s, ok := v.(Sample)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a Sample", ErrNotNumeric, v)
+	}
+	p := binary.LittleEndian.AppendUint64(nil, uint64(s.Time.UnixNano()))
+	return binary.LittleEndian.AppendUint64(p, math.Float64bits(s.Value)), nil
+}
+
+//DecodeSample decodes a Sample encoded by EncodeSample.
+func DecodeSample(p []byte) (interface{}, error) {
+	if len(p) != 16 {
+		return nil, fmt.Errorf("%w: sample of %d bytes", ErrSnapshot, len(p))
+	}
+	return Sample{
+		Time:  time.Unix(0, int64(binary.LittleEndian.Uint64(p))),
+		Value: math.Float64frombits(binary.LittleEndian.Uint64(p[8:])),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCodecs(t *testing.T) {
+	p, err := EncodeFloat64(1)
+	if err != nil || !bytes.Equal(p, []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}) {
+		t.Fatalf("EncodeFloat64 should be little-endian, got %x, %v", p, err)
+	}
+	if v, err := DecodeFloat64(p); err != nil || v != 1.0 {
+		t.Fatalf("DecodeFloat64 should return %v, got %v, %v", 1.0, v, err)
+	}
+	if _, err := EncodeFloat64("one"); !errors.Is(err, ErrNotNumeric) {
+		t.Fatalf("EncodeFloat64 should reject non numeric values, got %v", err)
+	}
+
+	s := Sample{Time: time.Unix(10, 20), Value: 2.5}
+	b := New(2)
+	b.Add(s)
+	data, err := b.MarshalSnapshot(EncodeSample)
+	if err != nil {
+		t.Fatalf("MarshalSnapshot failed: %v", err)
+	}
+	c, err := UnmarshalSnapshot(data, DecodeSample)
+	if err != nil {
+		t.Fatalf("UnmarshalSnapshot failed: %v", err)
+	}
+	if v, _ := c.Get(0); !v.(Sample).Time.Equal(s.Time) || v.(Sample).Value != s.Value {
+		t.Fatalf("Decoded sample should be %v, got %v", s, v)
+	}
+	if _, err := DecodeSample(p); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("DecodeSample should reject invalid lengths, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package ringbuffer
+
+//PushIfChanged adds 'value' to the ring, discarding the oldest value if the ring is full,
+//unless it equals the newest value according to 'eq' (== if nil).
+//
+// It returns true if 'value' was added: sampling loops do not fill the ring with identical consecutive values.
+func (b *Ring) PushIfChanged(value interface{}, eq func(x, y interface{}) bool) bool {
+	b.lock.Lock()
+	defer b.unlock()
+	return b.pushIfChanged(value, eq)
+}
+
+//pushIfChanged adds 'value' unless it equals the newest value (see PushIfChanged).
+func (b *ring) pushIfChanged(value interface{}, eq func(x, y interface{}) bool) bool {
+	if b.size > 0 && equal(eq, b.buf[b.index(0)], value) {
+		return false
+	}
+	b.put(value)
+	return true
+}
+
+//PushUnique adds 'value' to the ring, discarding the oldest value if the ring is full,
+//unless it equals any value in the ring according to 'eq' (== if nil), e.g. for bounded "recently seen" sets.
+//
+// It returns true if 'value' was added. See PushOrPromote to refresh the equal value instead.
+func (b *Ring) PushUnique(value interface{}, eq func(x, y interface{}) bool) bool {
+	b.lock.Lock()
+	defer b.unlock()
+	return b.pushUnique(value, eq, false)
+}
+
+//PushOrPromote is like PushUnique, but an equal value already in the ring is moved to the head instead,
+//becoming the newest value, as in a LRU cache. Handles and cursors follow the moved values (see Swap).
+//
+// It returns true if 'value' was added, false if an equal value was promoted.
+func (b *Ring) PushOrPromote(value interface{}, eq func(x, y interface{}) bool) bool {
+	b.lock.Lock()
+	defer b.unlock()
+	return b.pushUnique(value, eq, true)
+}
+
+//pushUnique adds 'value' unless it equals a value in the ring, promoting it if asked to (see PushUnique).
+func (b *ring) pushUnique(value interface{}, eq func(x, y interface{}) bool, promote bool) bool {
+	for i := 0; i < b.size; i++ {
+		if equal(eq, b.buf[b.index(i)], value) {
+			if promote {
+				b.move(i, 0)
+			}
+			return false
+		}
+	}
+	b.put(value)
+	return true
+}
+
+//move moves the value at the internal index 'from' to 'to', shifting the values in between, and their tags and generations.
+func (b *ring) move(from, to int) {
+	for ; from < to; from++ {
+		b.swap(b.logical(from), b.logical(from+1))
+	}
+	for ; from > to; from-- {
+		b.swap(b.logical(from), b.logical(from-1))
+	}
+}
+
+//equal compares 'x' and 'y' with 'eq', or == if nil.
+func equal(eq func(x, y interface{}) bool, x, y interface{}) bool {
+	if eq == nil {
+		return x == y
+	}
+	return eq(x, y)
+}
@@ -0,0 +1,64 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPushIfChanged(t *testing.T) {
+	b := New(3)
+	for _, v := range []interface{}{1, 1, 2, 2, 2, 1} {
+		b.PushIfChanged(v, nil)
+	}
+	if fmt.Sprint(b.Values()) != "[1 2 1]" {
+		t.Fatalf("Invalid values %v", b.Values())
+	}
+	if !b.PushIfChanged(3, nil) || fmt.Sprint(b.Values()) != "[2 1 3]" {
+		t.Fatalf("A changed value should be pushed, got %v", b.Values())
+	}
+	if b.PushIfChanged("3", func(x, y interface{}) bool { return fmt.Sprint(x) == fmt.Sprint(y) }) {
+		t.Fatalf("An equal value should not be pushed")
+	}
+	u := NewUnlocked(2)
+	u.PushIfChanged(1, nil)
+	if u.PushIfChanged(1, nil) || u.Size() != 1 {
+		t.Fatalf("An equal value should not be pushed, got %v", u.Values())
+	}
+}
+
+func TestPushUnique(t *testing.T) {
+	b := New(3)
+	for _, v := range []interface{}{1, 2, 1, 3, 2} {
+		b.PushUnique(v, nil)
+	}
+	if fmt.Sprint(b.Values()) != "[1 2 3]" {
+		t.Fatalf("Invalid values %v", b.Values())
+	}
+	if !b.PushUnique(4, nil) || b.PushUnique(3, nil) || fmt.Sprint(b.Values()) != "[2 3 4]" {
+		t.Fatalf("Invalid values %v", b.Values())
+	}
+
+	b = New(3)
+	b.PushTagged(1, "first")
+	b.PushOrPromote(2, nil)
+	h, _ := b.AddHandle(3)
+	if b.PushOrPromote(1, nil) || fmt.Sprint(b.Values()) != "[2 3 1]" {
+		t.Fatalf("An equal value should be promoted, got %v", b.Values())
+	}
+	if tags, _ := b.Tags(0); fmt.Sprint(tags) != "[first]" {
+		t.Fatalf("The promoted value should keep its tags, got %v", tags)
+	}
+	if v, ok := h.Get(); !ok || v != 3 {
+		t.Fatalf("A handle on a shifted value should follow it, got %v, %v", v, ok)
+	}
+	b.PushOrPromote(4, nil)
+	if fmt.Sprint(b.Values()) != "[3 1 4]" {
+		t.Fatalf("The least recently promoted value should be discarded, got %v", b.Values())
+	}
+
+	u := NewUnlocked(2)
+	u.PushUnique(1, nil)
+	if u.PushOrPromote(1, nil) || u.PushUnique(1, nil) || u.Size() != 1 {
+		t.Fatalf("Invalid values %v", u.Values())
+	}
+}
@@ -0,0 +1,89 @@
+package ringbuffer
+
+import (
+	"net"
+	"sync/atomic"
+)
+
+//BufferedConn is a net.Conn whose reads and writes go through byte rings.
+//
+// It keeps the last bytes received and sent for protocol debugging, meters the throughput,
+// and can peek at incoming bytes without consuming them.
+type BufferedConn struct {
+	net.Conn
+	pending       *ByteRing // read from the connection, but not yet consumed
+	read, written *ByteRing // the last bytes consumed and sent
+
+	bytesRead, bytesWritten atomic.Int64
+}
+
+//BufferConn wraps 'c', keeping the last 'readCap' bytes received and the last 'writeCap' bytes sent.
+//
+// Peek can look up to 'readCap' bytes ahead.
+func BufferConn(c net.Conn, readCap, writeCap int) *BufferedConn {
+	return &BufferedConn{
+		Conn:    c,
+		pending: NewByteRing(readCap),
+		read:    NewByteRing(readCap),
+		written: NewByteRing(writeCap),
+	}
+}
+
+//Read reads from the peeked bytes first, then from the connection.
+func (c *BufferedConn) Read(p []byte) (n int, err error) {
+	if c.pending.Len() > 0 {
+		n, _ = c.pending.Read(p)
+	} else {
+		n, err = c.Conn.Read(p)
+	}
+	c.read.Write(p[:n])
+	c.bytesRead.Add(int64(n))
+	return n, err
+}
+
+//Write writes to the connection.
+func (c *BufferedConn) Write(p []byte) (n int, err error) {
+	n, err = c.Conn.Write(p)
+	c.written.Write(p[:n])
+	c.bytesWritten.Add(int64(n))
+	return n, err
+}
+
+//Peek returns the next 'n' bytes, without consuming them.
+//
+// It blocks until 'n' bytes are received, and returns fewer bytes with the connection's error if any.
+// 'n' is limited to the read capacity.
+func (c *BufferedConn) Peek(n int) ([]byte, error) {
+	if n > c.pending.Cap() {
+		n = c.pending.Cap()
+	}
+	p := make([]byte, n)
+	for c.pending.Len() < n {
+		m, err := c.Conn.Read(p[:n-c.pending.Len()])
+		c.pending.Write(p[:m])
+		if err != nil {
+			return c.pending.Peek(c.pending.Len())
+		}
+	}
+	return c.pending.Peek(n)
+}
+
+//LastRead returns the last bytes consumed by Read, from the oldest to the newest.
+func (c *BufferedConn) LastRead() []byte {
+	return c.read.Bytes()
+}
+
+//LastWritten returns the last bytes sent by Write, from the oldest to the newest.
+func (c *BufferedConn) LastWritten() []byte {
+	return c.written.Bytes()
+}
+
+//BytesRead returns the number of bytes consumed by Read.
+func (c *BufferedConn) BytesRead() int64 {
+	return c.bytesRead.Load()
+}
+
+//BytesWritten returns the number of bytes sent by Write.
+func (c *BufferedConn) BytesWritten() int64 {
+	return c.bytesWritten.Load()
+}
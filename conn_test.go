@@ -0,0 +1,34 @@
+package ringbuffer
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+func TestBufferConn(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	c := BufferConn(server, 4, 4)
+	go func() {
+		client.Write([]byte("hello"))
+		io.ReadAll(client)
+	}()
+	p, err := c.Peek(3)
+	if err != nil || string(p) != "hel" {
+		t.Fatalf("Invalid peek %q, %v", p, err)
+	}
+	buf := make([]byte, 5)
+	n, _ := io.ReadFull(c, buf)
+	if string(buf[:n]) != "hello" {
+		t.Fatalf("Invalid read %q", buf[:n])
+	}
+	c.Write([]byte("world"))
+	if string(c.LastRead()) != "ello" || string(c.LastWritten()) != "orld" {
+		t.Fatalf("Invalid history %q %q", c.LastRead(), c.LastWritten())
+	}
+	if c.BytesRead() != 5 || c.BytesWritten() != 5 {
+		t.Fatalf("Invalid counts %v %v", c.BytesRead(), c.BytesWritten())
+	}
+	c.Close()
+}
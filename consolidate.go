@@ -0,0 +1,24 @@
+package ringbuffer
+
+//WithConsolidation makes Push fold the values it discards into 'fold', from the oldest to the newest,
+//so that no information is entirely lost when the ring wraps, e.g. aggregating them into per-minute summaries
+//added to a second ring.
+//
+// It includes the pushed values that never fit in the ring. 'fold' is called under the ring's lock,
+// it must not use the ring itself.
+func WithConsolidation(fold func(evicted interface{})) Option {
+	return func(b *ring) {
+		b.fold = fold
+	}
+}
+
+//consolidate folds the values discarded by pushing 'values' (see WithConsolidation).
+func (b *ring) consolidate(values []interface{}) {
+	n := min(len(values), b.size)
+	for i := 0; i < n; i++ {
+		b.fold(b.buf[b.index(-1-i)])
+	}
+	for _, v := range values[:len(values)-n] {
+		b.fold(v)
+	}
+}
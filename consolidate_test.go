@@ -0,0 +1,46 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConsolidation(t *testing.T) {
+	summary := New(10)
+	var folded []interface{}
+	b := New(3, WithConsolidation(func(v interface{}) { folded = append(folded, v) }))
+	b.Add(1, 2, 3)
+	b.Push(4)
+	b.Push(5, 6)
+	if fmt.Sprint(folded) != "[1 2 3]" || fmt.Sprint(b.Values()) != "[4 5 6]" {
+		t.Fatalf("Invalid folded values %v, ring %v", folded, b.Values())
+	}
+	folded = nil
+	b.Push(7, 8, 9, 10, 11)
+	if fmt.Sprint(folded) != "[4 5 6 7 8]" || fmt.Sprint(b.Values()) != "[9 10 11]" {
+		t.Fatalf("Values that never fit should be folded, got %v, ring %v", folded, b.Values())
+	}
+
+	// per-group sums added to a second ring
+	sum := 0
+	b = New(2, WithConsolidation(func(v interface{}) {
+		if sum += v.(int); v.(int)%2 == 0 {
+			summary.Add(sum)
+			sum = 0
+		}
+	}))
+	b.Add(1, 2)
+	b.Push(3, 4, 5, 6)
+	if fmt.Sprint(summary.Values()) != "[3 7]" {
+		t.Fatalf("Invalid summaries %v", summary.Values())
+	}
+
+	folded = nil
+	b = New(2, WithBudget(4, func(v interface{}) int { return len(v.(string)) }), WithConsolidation(func(v interface{}) { folded = append(folded, v) }))
+	b.Add("ab", "cd")
+	b.Push("efg")
+	b.Push("hijkl")
+	if fmt.Sprint(folded) != "[ab cd hijkl]" {
+		t.Fatalf("Invalid folded values in budget mode %v", folded)
+	}
+}
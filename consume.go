@@ -0,0 +1,66 @@
+package ringbuffer
+
+import (
+	"context"
+	"time"
+)
+
+//Consume repeatedly delivers batches of the oldest values to 'f', until 'ctx' is done (then it returns ctx.Err()).
+//
+// A batch is delivered as soon as it holds 'maxBatch' values, or 'maxWait' after its first value was available,
+// according to the ring's clock (see WithClock).
+// Values are removed from the ring only once 'f' succeeds: if it fails Consume returns its error,
+// and the batch is left in the ring, to be delivered again.
+//
+// The ring is not locked while 'f' runs: values pushed out of the ring meanwhile are simply not removed twice.
+func (b *Ring) Consume(ctx context.Context, maxBatch int, maxWait time.Duration, f func(batch []interface{}) error) error {
+	if maxBatch < 1 {
+		maxBatch = 1
+	}
+	full := func() bool { return b.Size() >= maxBatch }
+	for {
+		for !b.hasValues() {
+			if err := b.waiter.Wait(ctx, b.hasValues); err != nil {
+				return err
+			}
+		}
+		if !full() {
+			wait, cancel := b.withTimeout(ctx, maxWait)
+			for !full() && b.waiter.Wait(wait, full) == nil {
+			}
+			cancel()
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		b.lock.RLock()
+		batch := b.peek(maxBatch)
+		last := b.seq - uint64(b.size) + uint64(len(batch)) // sequence number of the batch's newest value
+		b.lock.RUnlock()
+		if err := f(batch); err != nil {
+			return err
+		}
+
+		b.lock.Lock()
+		if oldest := b.seq - uint64(b.size) + 1; last >= oldest {
+			b.evict(int(last - oldest + 1))
+		}
+		b.unlock()
+	}
+}
+
+//peek returns a copy of the (at most) 'n' oldest values, from the oldest to the newest.
+func (b *ring) peek(n int) []interface{} {
+	if n > b.size {
+		n = b.size
+	}
+	values := make([]interface{}, n)
+	if n == 0 {
+		return values
+	}
+	first, second := b.span(b.index(-1), n)
+	c := copy(values, first)
+	copy(values[c:], second)
+	return values
+}
@@ -0,0 +1,80 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestConsume(t *testing.T) {
+	b := New(10)
+	b.Add(1, 2, 3, 4, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	var batches [][]interface{}
+	err := b.Consume(ctx, 2, 10*time.Millisecond, func(batch []interface{}) error {
+		batches = append(batches, batch)
+		if len(batches) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Consume should return the context error, got %v", err)
+	}
+	if len(batches) != 3 || fmt.Sprint(batches) != "[[1 2] [3 4] [5]]" {
+		t.Fatalf("Invalid batches %v", batches)
+	}
+	if !b.IsEmpty() {
+		t.Fatalf("Consume should remove delivered values, got %v", b)
+	}
+
+	b.Add(1, 2)
+	failure := errors.New("failure")
+	err = b.Consume(context.Background(), 2, time.Second, func(batch []interface{}) error {
+		b.Push(3) // evicts 1 while the batch is consumed
+		return failure
+	})
+	if err != failure || fmt.Sprint(b.Values()) != "[2 3]" {
+		t.Fatalf("A failed batch should be left in the ring, got %v, %v", err, b)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	b.Consume(ctx, 2, time.Second, func(batch []interface{}) error {
+		b.Push(4) // evicts 2 while the batch is consumed
+		cancel()
+		return nil
+	})
+	if fmt.Sprint(b.Values()) != "[4]" {
+		t.Fatalf("Consume should only remove the values still in the ring, got %v", b)
+	}
+}
+
+func TestConsumeClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	b := New(10, WithClock(clock))
+	b.Add(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	batches := make(chan []interface{}, 1)
+	go b.Consume(ctx, 2, time.Millisecond, func(batch []interface{}) error {
+		batches <- batch
+		return nil
+	})
+	select {
+	case batch := <-batches:
+		t.Fatalf("A partial batch should wait for the ring's clock, got %v", batch)
+	case <-time.After(20 * time.Millisecond):
+	}
+	for {
+		clock.Advance(time.Millisecond)
+		select {
+		case batch := <-batches:
+			if fmt.Sprint(batch) != "[1]" {
+				t.Fatalf("Invalid batch %v", batch)
+			}
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package ringbuffer
+
+import stdring "container/ring"
+
+//FromContainerRing creates a ring with the values of a standard library's ring 'r'.
+//
+// The ring's capacity is r.Len(), and 'r' is considered as the oldest value, as when
+// it is used as a log (r.Value = v; r = r.Next()). nil values are skipped, as they are the
+// unused elements of a partially filled ring.
+func FromContainerRing(r *stdring.Ring, options ...Option) *Ring {
+	b := New(r.Len(), options...)
+	r.Do(func(v interface{}) {
+		if v != nil {
+			b.add(v)
+		}
+	})
+	b.publish()
+	return b
+}
+
+//ToContainerRing returns a standard library's ring with the ring's values.
+//
+// The returned element is the oldest value, and its Next ones are the newer values. It is nil for an empty ring.
+func (b *Ring) ToContainerRing() *stdring.Ring {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	r := stdring.New(b.size)
+	for i := b.size - 1; i >= 0; i-- {
+		r.Value = b.buf[b.index(i)]
+		r = r.Next()
+	}
+	return r
+}
@@ -0,0 +1,39 @@
+package ringbuffer
+
+import (
+	stdring "container/ring"
+	"testing"
+)
+
+func TestContainerRing(t *testing.T) {
+	r := stdring.New(5)
+	for i := 1; i <= 7; i++ { // use it as a log
+		r.Value = i
+		r = r.Next()
+	}
+	b := FromContainerRing(r)
+	if b.Capacity() != 5 || b.Size() != 5 || b.MustGet(0) != 7 || b.MustGet(-1) != 3 {
+		t.Fatalf("Invalid ring %v", b)
+	}
+
+	r = stdring.New(5)
+	r.Value = 1
+	r = r.Next()
+	b = FromContainerRing(r)
+	if b.Capacity() != 5 || b.Size() != 1 {
+		t.Fatalf("Invalid ring %v", b)
+	}
+
+	b = New(5)
+	b.Add(1, 2, 3)
+	b.Push(4)
+	r = b.ToContainerRing()
+	var values []interface{}
+	r.Do(func(v interface{}) { values = append(values, v) })
+	if len(values) != 3 || values[0] != 2 || values[2] != 4 {
+		t.Fatalf("Invalid values %v, expecting %v", values, []interface{}{2, 3, 4})
+	}
+	if New(1).ToContainerRing() != nil {
+		t.Fatalf("an empty ring should convert to nil")
+	}
+}
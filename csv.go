@@ -0,0 +1,79 @@
+package ringbuffer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+//WriteCSV writes the ring's values to 'w' as CSV, from the oldest to the newest.
+//
+// Samples are written as "time,value" rows (time in RFC 3339 format), and numbers as "value" rows.
+// It fails with ErrNotNumeric on other values.
+func (b *Ring) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	for i, v := range b.Values() {
+		var record []string
+		switch x := v.(type) {
+		case Sample:
+			record = []string{x.Time.Format(time.RFC3339Nano), strconv.FormatFloat(x.Value, 'g', -1, 64)}
+		default:
+			f, ok := number(v)
+			if !ok {
+				return fmt.Errorf("%w: %T at row %d", ErrNotNumeric, v, i+1)
+			}
+			record = []string{strconv.FormatFloat(f, 'g', -1, 64)}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//ReadCSV reads rows written by WriteCSV from 'r', and pushes them into the ring, discarding the oldest values if it is full.
+//
+// "time,value" rows are read as Samples, and "value" rows as float64.
+func (b *Ring) ReadCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		v, err := parseRecord(record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		b.lock.Lock()
+		b.put(v)
+		b.unlock()
+	}
+}
+
+//parseRecord parses a CSV record written by WriteCSV.
+func parseRecord(record []string) (interface{}, error) {
+	switch len(record) {
+	case 1:
+		return strconv.ParseFloat(record[0], 64)
+	case 2:
+		t, err := time.Parse(time.RFC3339Nano, record[0])
+		if err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		return Sample{Time: t, Value: f}, nil
+	}
+	return nil, fmt.Errorf("%w: %d fields", ErrNotNumeric, len(record))
+}
@@ -0,0 +1,38 @@
+package ringbuffer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSV(t *testing.T) {
+	t0 := time.Date(2014, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := New(3)
+	b.Add(Sample{t0, 1.5}, Sample{t0.Add(time.Second), 2})
+	var out strings.Builder
+	if err := b.WriteCSV(&out); err != nil {
+		t.Fatal(err.Error())
+	}
+	want := "2014-01-02T03:04:05Z,1.5\n2014-01-02T03:04:06Z,2\n"
+	if out.String() != want {
+		t.Fatalf("Invalid CSV %q, expecting %q", out.String(), want)
+	}
+
+	c := New(2)
+	if err := c.ReadCSV(strings.NewReader(want + "3\n")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if c.MustGet(0) != 3.0 || c.MustGet(1) != (Sample{t0.Add(time.Second), 2}) {
+		t.Fatalf("Invalid ring %v", c)
+	}
+	if err := c.ReadCSV(strings.NewReader("1\nx\n")); err == nil {
+		t.Fatalf("should have failed to parse 'x'")
+	}
+
+	b.Add("text")
+	if err := b.WriteCSV(&out); !errors.Is(err, ErrNotNumeric) {
+		t.Fatalf("should have failed with ErrNotNumeric, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package ringbuffer
+
+//Cursor designates a value of a ring by its generation (see GetStamped), rather than by its index:
+// it keeps designating the same value while new ones are pushed, shifting the indexes, or the ring is reordered,
+// until it is evicted.
+//
+// A cursor is not safe for concurrent use, but its ring is.
+type Cursor struct {
+	ring *Ring
+	seq  uint64 // the value's generation
+}
+
+//Cursor returns a cursor on the value at index 'i' (see Get).
+func (b *Ring) Cursor(i int) (*Cursor, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	_, seq, err := b.getStamped(i)
+	if err != nil {
+		return nil, err
+	}
+	return &Cursor{ring: b, seq: seq}, nil
+}
+
+//Get returns the value under the cursor, and false if it has been evicted.
+func (c *Cursor) Get() (v interface{}, ok bool) {
+	b := c.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	seq, ok := b.resolve(c.seq)
+	if !ok {
+		return nil, false
+	}
+	return b.buf[b.index(int(b.seq-seq))], true
+}
+
+//Index returns the current index of the value under the cursor (see Get), and false if it has been evicted.
+func (c *Cursor) Index() (int, bool) {
+	b := c.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	seq, ok := b.resolve(c.seq)
+	if !ok {
+		return 0, false
+	}
+	if b.oldestFirst {
+		return int(seq - (b.seq - uint64(b.size) + 1)), true
+	}
+	return int(b.seq - seq), true
+}
+
+//Sequence returns the generation of the value under the cursor (see GetStamped).
+func (c *Cursor) Sequence() uint64 {
+	return c.seq
+}
+
+//Next moves the cursor to the next (newer) value, it returns false and does not move if there is none.
+//
+// If the value under the cursor has been evicted, it moves to the oldest value instead.
+// If the ring has been emptied, it returns false, and the next call moves to the next value added.
+func (c *Cursor) Next() bool {
+	b := c.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if b.size == 0 {
+		if c.seq < b.seq {
+			c.seq = b.seq // just before the next value added
+		}
+		return false
+	}
+	seq, ok := b.resolve(c.seq)
+	if !ok {
+		c.seq = b.generation(b.seq - uint64(b.size) + 1)
+		return true
+	}
+	if seq >= b.seq {
+		return false
+	}
+	c.seq = b.generation(seq + 1)
+	return true
+}
+
+//Prev moves the cursor to the previous (older) value, it returns false and does not move if there is none.
+func (c *Cursor) Prev() bool {
+	b := c.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	seq, ok := b.resolve(c.seq)
+	if !ok || !b.stillValid(seq-1) {
+		return false
+	}
+	c.seq = b.generation(seq - 1)
+	return true
+}
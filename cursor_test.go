@@ -0,0 +1,50 @@
+package ringbuffer
+
+import "testing"
+
+func TestCursor(t *testing.T) {
+	b := New(3)
+	if _, err := b.Cursor(0); err != ErrEmpty {
+		t.Fatalf("Cursor should fail on an empty ring, got %v", err)
+	}
+	b.Add(1, 2)
+	c, _ := b.Cursor(0)
+	b.Add(3)
+	if v, ok := c.Get(); !ok || v != 2 {
+		t.Fatalf("Cursor should follow its value, got %v, %v", v, ok)
+	}
+	if i, ok := c.Index(); !ok || i != 1 {
+		t.Fatalf("Cursor index should be %v, got %v, %v", 1, i, ok)
+	}
+	if !c.Prev() || c.Prev() {
+		t.Fatalf("Cursor should move back to the oldest value, and no further")
+	}
+	b.Push(4)
+	if _, ok := c.Get(); ok {
+		t.Fatalf("Cursor should report its value's eviction")
+	}
+	if !c.Next() {
+		t.Fatalf("Next should move an evicted cursor to the oldest value")
+	}
+	if v, _ := c.Get(); v != 2 {
+		t.Fatalf("Cursor should be on %v, got %v", 2, v)
+	}
+	for c.Next() {
+	}
+	if v, _ := c.Get(); v != 4 || c.Sequence() != 4 {
+		t.Fatalf("Cursor should stop on the newest value, got %v", v)
+	}
+	b.Remove(1)
+	c.Prev()
+	b.Reset(3)
+	if c.Next() || c.Sequence() != 4 {
+		t.Fatalf("Next should not move on an emptied ring, got %v", c.Sequence())
+	}
+	b.Add(5)
+	if !c.Next() {
+		t.Fatalf("Next should move to the value added after the ring was emptied")
+	}
+	if v, _ := c.Get(); v != 5 {
+		t.Fatalf("Cursor should be on %v, got %v", 5, v)
+	}
+}
@@ -0,0 +1,103 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"os"
+)
+
+//encryptedMagic starts every encrypted ring file (see MarshalFileEncrypted).
+const encryptedMagic = "RBUE"
+
+//MarshalFileEncrypted encodes the ring as a ring file (see MarshalFile), encrypted with 'key' (see EncryptSnapshot).
+//
+// The result is prefixed with the magic "RBUE": UnmarshalFile and Migrate refuse it with ErrEncrypted,
+// rather than mistaking it for a ring file. MappedRing files are not encrypted.
+func (b *Ring) MarshalFileEncrypted(encode func(v interface{}) ([]byte, error), key []byte) ([]byte, error) {
+	data, err := b.MarshalFile(encode)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := EncryptSnapshot(data, key)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(encryptedMagic), sealed...), nil
+}
+
+//UnmarshalFileEncrypted creates a ring from an encrypted ring file (see MarshalFileEncrypted), of any version.
+//
+// Data encrypted by EncryptSnapshot, without the "RBUE" magic, are accepted too.
+// It fails with ErrSnapshot if the data has been altered, or 'key' is not the right one.
+func UnmarshalFileEncrypted(data, key []byte, decode func(p []byte) (interface{}, error), options ...Option) (*Ring, error) {
+	plain, err := DecryptSnapshot(bytes.TrimPrefix(data, []byte(encryptedMagic)), key)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalFile(plain, decode, options...)
+}
+
+//SaveFileEncrypted writes the ring, encrypted with 'key', to the file at 'path', replacing it atomically (see MarshalFileEncrypted).
+func (b *Ring) SaveFileEncrypted(path string, encode func(v interface{}) ([]byte, error), key []byte) error {
+	data, err := b.MarshalFileEncrypted(encode, key)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+//LoadFileEncrypted creates a ring from the encrypted file at 'path' (see UnmarshalFileEncrypted).
+func LoadFileEncrypted(path string, key []byte, decode func(p []byte) (interface{}, error), options ...Option) (*Ring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalFileEncrypted(data, key, decode, options...)
+}
+
+//EncryptSnapshot encrypts and authenticates a persisted ring (see MarshalSnapshot) with AES-GCM.
+//
+// 'key' is supplied by the caller, and must be 16, 24 or 32 bytes long (AES-128, AES-192 or AES-256).
+// A random nonce is generated and prepended to the result.
+func EncryptSnapshot(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(data)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+//DecryptSnapshot decrypts data encrypted by EncryptSnapshot.
+//
+// It fails with ErrSnapshot if the data has been altered, or 'key' is not the right one.
+func DecryptSnapshot(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: encrypted data too short", ErrSnapshot)
+	}
+	nonce, data := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshot, err)
+	}
+	return plain, nil
+}
+
+//newGCM creates the AES-GCM cipher for 'key'.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,79 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptSnapshot(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	data := []byte("some personal data")
+	sealed, err := EncryptSnapshot(data, key)
+	if err != nil {
+		t.Fatalf("EncryptSnapshot failed: %v", err)
+	}
+	if bytes.Contains(sealed, data) {
+		t.Fatalf("EncryptSnapshot should not leak the data")
+	}
+	plain, err := DecryptSnapshot(sealed, key)
+	if err != nil || !bytes.Equal(plain, data) {
+		t.Fatalf("DecryptSnapshot should return %q, got %q, %v", data, plain, err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := DecryptSnapshot(sealed, key); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("DecryptSnapshot should detect altered data, got %v", err)
+	}
+	if _, err := DecryptSnapshot(sealed[:3], key); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("DecryptSnapshot should detect truncated data, got %v", err)
+	}
+	if _, err := EncryptSnapshot(data, key[:5]); err == nil {
+		t.Fatalf("EncryptSnapshot should reject invalid keys")
+	}
+}
+
+func TestFileEncrypted(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	path := filepath.Join(t.TempDir(), "ring")
+	b := New(3)
+	b.Add("secret", "data")
+	if err := b.SaveFileEncrypted(path, encodeString, key); err != nil {
+		t.Fatalf("SaveFileEncrypted failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !bytes.HasPrefix(data, []byte("RBUE")) || bytes.Contains(data, []byte("secret")) {
+		t.Fatalf("The file should be encrypted, got %q", data)
+	}
+	if c, err := LoadFileEncrypted(path, key, decodeString); err != nil || fmt.Sprint(c.Values()) != "[secret data]" {
+		t.Fatalf("LoadFileEncrypted should return %v, got %v, %v", b, c, err)
+	}
+	if _, err := LoadFileEncrypted(path, bytes.Repeat([]byte{8}, 32), decodeString); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("A wrong key should fail with ErrSnapshot, got %v", err)
+	}
+	if _, err := LoadFile(path, decodeString); !errors.Is(err, ErrEncrypted) {
+		t.Fatalf("LoadFile should refuse an encrypted file, got %v", err)
+	}
+	if err := Migrate(path); !errors.Is(err, ErrEncrypted) {
+		t.Fatalf("Migrate should refuse an encrypted file, got %v", err)
+	}
+	if migrated, _ := os.ReadFile(path); !bytes.Equal(migrated, data) {
+		t.Fatalf("Migrate should leave an encrypted file untouched")
+	}
+
+	// encrypted by EncryptSnapshot, without any header
+	snapshot, _ := b.MarshalSnapshot(encodeString)
+	legacy, _ := EncryptSnapshot(snapshot, key)
+	os.WriteFile(path, legacy, 0o644)
+	if err := Migrate(path); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("Migrate should refuse data that is not a ring file, got %v", err)
+	}
+	if migrated, _ := os.ReadFile(path); !bytes.Equal(migrated, legacy) {
+		t.Fatalf("Migrate should leave data that is not a ring file untouched")
+	}
+	if c, err := LoadFileEncrypted(path, key, decodeString); err != nil || fmt.Sprint(c.Values()) != "[secret data]" {
+		t.Fatalf("LoadFileEncrypted should read data encrypted by EncryptSnapshot, got %v, %v", c, err)
+	}
+}
@@ -0,0 +1,96 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"sync"
+)
+
+//Event is a value appended to an EventLog, with its version.
+type Event struct {
+	Version uint64 // starting at 1
+	Value   interface{}
+}
+
+//EventLog is an in-memory event-sourcing log, retaining the last events.
+//
+// Read models can be rebuilt from the retained events (Since), and kept up to date by subscribing to new ones.
+type EventLog struct {
+	lock sync.Mutex
+	ring *Unlocked
+	subs map[chan Event]struct{}
+}
+
+//NewEventLog creates a log retaining the last 'capacity' events.
+func NewEventLog(capacity int) *EventLog {
+	return &EventLog{ring: NewUnlocked(capacity), subs: make(map[chan Event]struct{})}
+}
+
+//Append adds 'value' as a new event, discarding the oldest if the log is full, and returns it.
+func (l *EventLog) Append(value interface{}) Event {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	e := Event{Version: l.ring.seq + 1, Value: value}
+	l.ring.put(e)
+	for c := range l.subs {
+		select {
+		case c <- e:
+		default: // too slow, it must resynchronize with Since
+			delete(l.subs, c)
+			close(c)
+		}
+	}
+	return e
+}
+
+//Version returns the last event's version, 0 if there is none.
+func (l *EventLog) Version() uint64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.ring.seq
+}
+
+//Since returns the events after 'version', from the oldest to the newest.
+//
+// It fails with ErrRange if some of them are no longer retained.
+func (l *EventLog) Since(version uint64) ([]Event, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.since(version)
+}
+
+//since returns the events after 'version' (see Since).
+func (l *EventLog) since(version uint64) ([]Event, error) {
+	oldest := l.ring.seq - uint64(l.ring.size) + 1
+	if version+1 < oldest {
+		return nil, fmt.Errorf("%w: events since version %d are evicted, the oldest is %d", ErrRange, version, oldest)
+	}
+	var events []Event
+	for v := version + 1; v <= l.ring.seq; v++ {
+		events = append(events, l.ring.buf[l.ring.index(int(l.ring.seq-v))].(Event))
+	}
+	return events, nil
+}
+
+//Subscribe returns the events after 'version', and a channel receiving the next events.
+//
+// The channel buffers up to 'buffer' events: it is closed if the subscriber falls further behind,
+// it must then resynchronize with Since. Call cancel to unsubscribe.
+func (l *EventLog) Subscribe(version uint64, buffer int) (events []Event, c <-chan Event, cancel func(), err error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	events, err = l.since(version)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ch := make(chan Event, buffer)
+	l.subs[ch] = struct{}{}
+	cancel = func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		if _, ok := l.subs[ch]; ok {
+			delete(l.subs, ch)
+			close(ch)
+		}
+	}
+	return events, ch, cancel, nil
+}
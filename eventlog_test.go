@@ -0,0 +1,44 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEventLog(t *testing.T) {
+	l := NewEventLog(3)
+	for i := 0; i < 4; i++ {
+		l.Append(i)
+	}
+	if l.Version() != 4 {
+		t.Fatalf("Invalid version %v, expecting %v", l.Version(), 4)
+	}
+	events, err := l.Since(2)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(events) != 2 || events[0] != (Event{3, 2}) || events[1] != (Event{4, 3}) {
+		t.Fatalf("Invalid events %v", events)
+	}
+	if _, err := l.Since(0); !errors.Is(err, ErrRange) {
+		t.Fatalf("should have failed with ErrRange, got %v", err)
+	}
+
+	events, c, cancel, err := l.Subscribe(3, 1)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Invalid subscription %v, %v", events, err)
+	}
+	l.Append(4)
+	if e := <-c; e.Version != 5 {
+		t.Fatalf("Invalid event %v", e)
+	}
+	l.Append(5)
+	l.Append(6) // too slow
+	if e, ok := <-c; !ok || e.Version != 6 {
+		t.Fatalf("Invalid event %v", e)
+	}
+	if _, ok := <-c; ok {
+		t.Fatalf("the channel of a slow subscriber should be closed")
+	}
+	cancel()
+}
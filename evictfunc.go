@@ -0,0 +1,40 @@
+package ringbuffer
+
+import "fmt"
+
+//PushEvictFunc adds 'value' to the ring's head, letting 'choose' decide which value to discard when the ring is full,
+//e.g. the lowest-priority one rather than the oldest.
+//
+// 'choose' receives a copy of the ring's values, from the oldest to the newest, and returns the index of the one to discard;
+// it panics with ErrRange if the index is out of the candidates. In budget mode 'choose' is called until 'value' fits,
+// and a value bigger than the whole budget is discarded instead.
+// The values older than the discarded one are shifted, their tags, handles and cursors follow them (see Swap).
+func (b *Ring) PushEvictFunc(value interface{}, choose func(candidates []interface{}) int) {
+	b.lock.Lock()
+	defer b.unlock()
+	b.pushEvictFunc(value, choose)
+}
+
+//pushEvictFunc adds 'value', discarding the values chosen by 'choose' (see PushEvictFunc).
+func (b *ring) pushEvictFunc(value interface{}, choose func(candidates []interface{}) int) {
+	if b.reserved >= b.capacity || (b.sizeOf != nil && b.sizeOf(value) > b.budget) { // it will never fit
+		b.stats.dropped.Add(1)
+		if b.fold != nil {
+			b.fold(value)
+		}
+		return
+	}
+	for b.addOne(value) != nil {
+		k := choose(b.values())
+		if k < 0 || k >= b.size {
+			panic(fmt.Errorf("%w: index %d out of %d candidates", ErrRange, k, b.size))
+		}
+		// move the chosen value to the tail, keeping the order of the others, and discard it
+		b.move(b.size-1-k, b.size-1)
+		if b.fold != nil {
+			b.fold(b.buf[b.index(-1)])
+		}
+		b.evict(1)
+		b.stats.dropped.Add(1)
+	}
+}
@@ -0,0 +1,62 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPushEvictFunc(t *testing.T) {
+	lowest := func(candidates []interface{}) int {
+		k := 0
+		for i, v := range candidates {
+			if v.(int) < candidates[k].(int) {
+				k = i
+			}
+		}
+		return k
+	}
+	b := New(3)
+	b.PushEvictFunc(5, lowest)
+	b.PushEvictFunc(1, lowest)
+	b.PushTagged(7, "keep")
+	b.PushEvictFunc(3, lowest)
+	if fmt.Sprint(b.Values()) != "[5 7 3]" || b.Dropped() != 1 {
+		t.Fatalf("Invalid values %v, dropped %v", b.Values(), b.Dropped())
+	}
+	if fmt.Sprint(b.ValuesTagged("keep")) != "[7]" {
+		t.Fatalf("Tags should follow their values, got %v", b.ValuesTagged("keep"))
+	}
+	five, _ := b.Get(2)
+	h5, _ := b.Cursor(2)
+	h7, _ := b.Cursor(1)
+	b.PushEvictFunc(9, lowest)
+	if fmt.Sprint(b.Values()) != "[5 7 9]" {
+		t.Fatalf("Invalid values %v", b.Values())
+	}
+	if v, ok := h5.Get(); !ok || v != five {
+		t.Fatalf("A cursor on a shifted value should follow it, got %v, %v", v, ok)
+	}
+	if v, ok := h7.Get(); !ok || v != 7 {
+		t.Fatalf("A cursor on a newer value should keep it, got %v, %v", v, ok)
+	}
+
+	u := NewUnlocked(2, WithBudget(4, func(v interface{}) int { return v.(int) }))
+	u.PushEvictFunc(1, lowest)
+	u.PushEvictFunc(2, lowest)
+	u.PushEvictFunc(3, lowest)
+	if fmt.Sprint(u.Values()) != "[3]" {
+		t.Fatalf("Values should be discarded until the value fits, got %v", u.Values())
+	}
+	u.PushEvictFunc(5, lowest)
+	if fmt.Sprint(u.Values()) != "[3]" || u.Dropped() != 3 {
+		t.Fatalf("An oversize value should be discarded, got %v, dropped %v", u.Values(), u.Dropped())
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrRange) {
+			t.Fatalf("An invalid choice should panic with ErrRange, got %v", err)
+		}
+	}()
+	b.PushEvictFunc(0, func([]interface{}) int { return 3 })
+}
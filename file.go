@@ -0,0 +1,148 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+//FileVersion is the version of the ring file layout written by MarshalFile.
+//
+// Version 0 is the bare snapshot, without header (see MarshalSnapshot).
+// Version 1 prefixes it with a header: the magic "RBUF" and the version byte.
+const FileVersion = 1
+
+//fileMagic starts every ring file since version 1.
+const fileMagic = "RBUF"
+
+//migrations upgrade a file payload from the version at their index to the next one.
+var migrations = []func(payload []byte) ([]byte, error){
+	0: func(payload []byte) ([]byte, error) { return payload, nil }, // 1 only adds the header
+}
+
+//MarshalFile encodes the ring as a ring file: a versioned header followed by the snapshot (see MarshalSnapshot).
+func (b *Ring) MarshalFile(encode func(v interface{}) ([]byte, error)) ([]byte, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.marshalFile(encode)
+}
+
+//marshalFile encodes the ring as a ring file (see MarshalFile).
+func (b *ring) marshalFile(encode func(v interface{}) ([]byte, error)) ([]byte, error) {
+	data, err := b.marshalSnapshot(encode)
+	if err != nil {
+		return nil, err
+	}
+	return append(fileHeader(), data...), nil
+}
+
+//UnmarshalFile creates a ring from a ring file, of any version (see MarshalFile).
+func UnmarshalFile(data []byte, decode func(p []byte) (interface{}, error), options ...Option) (*Ring, error) {
+	payload, err := upgrade(data)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalSnapshot(payload, decode, options...)
+}
+
+//SaveFile writes the ring to the file at 'path', replacing it atomically.
+func (b *Ring) SaveFile(path string, encode func(v interface{}) ([]byte, error)) error {
+	data, err := b.MarshalFile(encode)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+//LoadFile creates a ring from the file at 'path' (see UnmarshalFile).
+func LoadFile(path string, decode func(p []byte) (interface{}, error), options ...Option) (*Ring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalFile(data, decode, options...)
+}
+
+//Migrate upgrades the ring file at 'path' to the current FileVersion, in place.
+//
+// Files already up to date are left untouched. Encrypted files fail with ErrEncrypted: they are upgraded when loaded
+// (see LoadFileEncrypted). Files without header (version 0) are left untouched unless they decode as a snapshot.
+func Migrate(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	version, payload, err := fileVersion(data)
+	if err != nil || version == FileVersion {
+		return err
+	}
+	if version == 0 { // make sure it is not something else, like data encrypted by EncryptSnapshot
+		if _, err := UnmarshalSnapshot(payload, func(p []byte) (interface{}, error) { return p, nil }); err != nil {
+			return fmt.Errorf("not a ring file: %w", err)
+		}
+	}
+	payload, err = upgrade(data)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, append(fileHeader(), payload...))
+}
+
+//fileHeader returns the header of the current version.
+func fileHeader() []byte {
+	return append([]byte(fileMagic), FileVersion)
+}
+
+//fileVersion returns the version of a ring file, and its payload.
+func fileVersion(data []byte) (version int, payload []byte, err error) {
+	if bytes.HasPrefix(data, []byte(encryptedMagic)) {
+		return 0, nil, ErrEncrypted
+	}
+	if !bytes.HasPrefix(data, []byte(fileMagic)) {
+		return 0, data, nil
+	}
+	if len(data) < len(fileMagic)+1 {
+		return 0, nil, fmt.Errorf("%w: truncated file header", ErrSnapshot)
+	}
+	version = int(data[len(fileMagic)])
+	if version > FileVersion {
+		return 0, nil, fmt.Errorf("%w: unsupported file version %d", ErrSnapshot, version)
+	}
+	return version, data[len(fileMagic)+1:], nil
+}
+
+//upgrade returns the payload of a ring file, migrated to the current version.
+func upgrade(data []byte) ([]byte, error) {
+	version, payload, err := fileVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	for ; version < FileVersion; version++ {
+		if payload, err = migrations[version](payload); err != nil {
+			return nil, fmt.Errorf("migrating from version %d: %w", version, err)
+		}
+	}
+	return payload, nil
+}
+
+//writeFile writes 'data' to a temporary file, renamed to 'path' once complete.
+func writeFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op once renamed
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
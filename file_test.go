@@ -0,0 +1,56 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func encodeString(v interface{}) ([]byte, error) { return []byte(v.(string)), nil }
+func decodeString(p []byte) (interface{}, error) { return string(p), nil }
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	b := New(3)
+	b.Add("a", "b")
+	if err := b.SaveFile(path, encodeString); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !bytes.HasPrefix(data, []byte("RBUF\x01")) {
+		t.Fatalf("Invalid file header %q", data)
+	}
+	c, err := LoadFile(path, decodeString)
+	if err != nil || fmt.Sprint(c.Values()) != "[a b]" || c.Capacity() != 3 {
+		t.Fatalf("LoadFile should return %v, got %v, %v", b, c, err)
+	}
+
+	data[4] = FileVersion + 1
+	if _, err := UnmarshalFile(data, decodeString); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("UnmarshalFile should reject future versions, got %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	b := New(3)
+	b.Add("a", "b")
+	legacy, _ := b.MarshalSnapshot(encodeString)
+	os.WriteFile(path, legacy, 0o644)
+	if c, err := LoadFile(path, decodeString); err != nil || fmt.Sprint(c.Values()) != "[a b]" {
+		t.Fatalf("LoadFile should read version 0 files, got %v, %v", c, err)
+	}
+	if err := Migrate(path); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !bytes.Equal(data, append([]byte("RBUF\x01"), legacy...)) {
+		t.Fatalf("Invalid migrated file %q", data)
+	}
+	if err := Migrate(path); err != nil {
+		t.Fatalf("Migrate should leave up to date files untouched, got %v", err)
+	}
+}
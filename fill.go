@@ -0,0 +1,32 @@
+package ringbuffer
+
+//NewFilled creates a new ring buffer, full of 'fill' values.
+//
+// Sliding-window filters (like moving averages) then start from a full window of defaults, their first outputs
+// are not skewed by a short window. In budget mode, the ring is filled up to its budget instead.
+func NewFilled(capacity int, fill interface{}, options ...Option) (b *Ring) {
+	b = New(capacity, options...)
+	b.Fill(fill)
+	return b
+}
+
+//Fill replaces the ring's values by 'value' repeated up to the ring's capacity (see NewFilled).
+func (b *Ring) Fill(value interface{}) {
+	b.lock.Lock()
+	defer b.unlock()
+	b.fill(value)
+}
+
+//fill replaces the ring's values by 'value' repeated up to the capacity (see Fill).
+func (b *ring) fill(value interface{}) {
+	b.remove(b.size)
+	for b.addOne(value) == nil {
+	}
+}
+
+//NewUnlockedFilled creates a new, unsynchronized ring buffer, full of 'fill' values (see NewFilled).
+func NewUnlockedFilled(capacity int, fill interface{}, options ...Option) (b *Unlocked) {
+	b = NewUnlocked(capacity, options...)
+	b.fill(fill)
+	return b
+}
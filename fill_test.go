@@ -0,0 +1,33 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFill(t *testing.T) {
+	b := NewFilled(3, 0)
+	if fmt.Sprint(b.Values()) != "[0 0 0]" || !b.IsFull() {
+		t.Fatalf("Invalid filled ring %v", b.Values())
+	}
+	b.Push(1)
+	b.Fill(2)
+	if fmt.Sprint(b.Values()) != "[2 2 2]" {
+		t.Fatalf("Invalid filled ring %v", b.Values())
+	}
+	b = NewFilled(3, "ab", WithBudget(5, func(v interface{}) int { return len(v.(string)) }))
+	if fmt.Sprint(b.Values()) != "[ab ab]" {
+		t.Fatalf("A ring should be filled up to its budget, got %v", b.Values())
+	}
+	u := NewUnlockedFilled(2, 1.5, WithLazyAllocation())
+	if fmt.Sprint(u.Values()) != "[1.5 1.5]" {
+		t.Fatalf("Invalid filled ring %v", u.Values())
+	}
+	u.Fill(nil)
+	if fmt.Sprint(u.Values()) != "[<nil> <nil>]" {
+		t.Fatalf("Invalid filled ring %v", u.Values())
+	}
+	if NewFilled(0, 1).Size() != 0 {
+		t.Fatalf("A ring without capacity cannot be filled")
+	}
+}
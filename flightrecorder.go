@@ -0,0 +1,78 @@
+package ringbuffer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+//FlightRecorder turns a ring into a post-mortem black box: its values are dumped when the process is about to die.
+//
+// It dumps on the signals it watches, and on panics in the goroutines deferring Recover.
+// Values are formatted by the ring's formatter (see Ring.SetFormatter).
+type FlightRecorder struct {
+	ring *Ring
+	w    io.Writer
+	lock sync.Mutex // serializes dumps
+
+	signals chan os.Signal
+	runner  *Runner
+}
+
+//NewFlightRecorder creates a recorder dumping 'b' to 'w' (typically os.Stderr or a file).
+func NewFlightRecorder(b *Ring, w io.Writer) *FlightRecorder {
+	return &FlightRecorder{ring: b, w: w}
+}
+
+//Dump writes the ring's values to the writer, with a header stating why.
+func (f *FlightRecorder) Dump(reason string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, err := fmt.Fprintf(f.w, "flight recorder dump (%s): %d values\n", reason, f.ring.Size()); err != nil {
+		return err
+	}
+	return f.ring.Dump(f.w)
+}
+
+//Recover dumps the ring if the goroutine is panicking, and then panics again.
+//
+// It must be deferred directly: defer recorder.Recover().
+func (f *FlightRecorder) Recover() {
+	if r := recover(); r != nil {
+		f.Dump(fmt.Sprintf("panic: %v", r))
+		panic(r)
+	}
+}
+
+//Watch dumps the ring when the process receives one of 'signals' (e.g. os.Interrupt),
+// and then lets the signal terminate the process as if it was not watched.
+func (f *FlightRecorder) Watch(signals ...os.Signal) {
+	received := make(chan os.Signal, 1)
+	f.signals, f.runner = received, new(Runner)
+	signal.Notify(received, signals...)
+	f.runner.Start(func(stop <-chan struct{}) {
+		select {
+		case sig := <-received:
+			f.Dump(fmt.Sprintf("signal: %v", sig))
+			signal.Reset(signals...)
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				p.Signal(sig)
+			}
+		case <-stop:
+		}
+	})
+}
+
+//Stop stops watching signals.
+func (f *FlightRecorder) Stop() {
+	if f.signals == nil {
+		return
+	}
+	signal.Stop(f.signals)
+	f.runner.Stop()
+	f.runner.Wait(context.Background())
+	f.signals = nil
+}
@@ -0,0 +1,28 @@
+package ringbuffer
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	b := New(2)
+	b.Add("a", "b")
+	var out strings.Builder
+	f := NewFlightRecorder(b, &out)
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("Recover should panic again, got %v", r)
+			}
+		}()
+		defer f.Recover()
+		panic("boom")
+	}()
+	if out.String() != "flight recorder dump (panic: boom): 2 values\na\nb\n" {
+		t.Fatalf("Invalid dump %q", out.String())
+	}
+	f.Watch(os.Interrupt)
+	f.Stop()
+}
@@ -0,0 +1,69 @@
+package ringbuffer
+
+import "sync"
+
+//FrameBuffer is a drop-oldest buffer for camera or sensor capture pipelines, that accounts for the frames it drops.
+type FrameBuffer struct {
+	lock  sync.Mutex
+	ring  *Unlocked
+	stats FrameStats // for the current interval
+}
+
+//FrameStats describes a FrameBuffer's activity over an interval.
+type FrameStats struct {
+	Written uint64 // frames put
+	Dropped uint64 // frames discarded before being consumed
+	MaxLag  int    // maximum number of frames waiting when the consumer took one
+}
+
+//DropRatio returns the ratio of frames written that were dropped.
+func (s FrameStats) DropRatio() float64 {
+	if s.Written == 0 {
+		return 0
+	}
+	return float64(s.Dropped) / float64(s.Written)
+}
+
+//NewFrameBuffer creates a buffer of 'capacity' frames.
+func NewFrameBuffer(capacity int) *FrameBuffer {
+	return &FrameBuffer{ring: NewUnlocked(capacity)}
+}
+
+//Put adds 'frame', dropping the oldest frame if the buffer is full.
+func (f *FrameBuffer) Put(frame interface{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.stats.Written++
+	if f.ring.size == f.ring.capacity {
+		f.stats.Dropped++
+	}
+	f.ring.put(frame)
+}
+
+//Next consumes the oldest frame, it returns false if there is none.
+func (f *FrameBuffer) Next() (interface{}, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.ring.size > f.stats.MaxLag {
+		f.stats.MaxLag = f.ring.size
+	}
+	return f.ring.pop()
+}
+
+//Len returns the number of frames waiting.
+func (f *FrameBuffer) Len() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.ring.size
+}
+
+//Stats returns the statistics since the previous call, and starts a new interval.
+//
+// Call it periodically (e.g. every second) to get per-interval drop ratios.
+func (f *FrameBuffer) Stats() FrameStats {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	s := f.stats
+	f.stats = FrameStats{}
+	return s
+}
@@ -0,0 +1,24 @@
+package ringbuffer
+
+import "testing"
+
+func TestFrameBuffer(t *testing.T) {
+	f := NewFrameBuffer(2)
+	for i := 0; i < 5; i++ {
+		f.Put(i)
+	}
+	if v, _ := f.Next(); v != 3 {
+		t.Fatalf("Next() = %v, expecting %v", v, 3)
+	}
+	s := f.Stats()
+	if s.Written != 5 || s.Dropped != 3 || s.MaxLag != 2 || s.DropRatio() != 0.6 {
+		t.Fatalf("Invalid stats %+v", s)
+	}
+	f.Next()
+	if _, ok := f.Next(); ok {
+		t.Fatalf("Next should fail on an empty buffer")
+	}
+	if s := f.Stats(); s.Written != 0 || s.MaxLag != 1 || s.DropRatio() != 0 {
+		t.Fatalf("Invalid stats %+v", s)
+	}
+}
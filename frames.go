@@ -0,0 +1,89 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"sync"
+)
+
+//FrameRing is a ring of fixed-size audio frames, for DSP and realtime callbacks.
+//
+// A frame is made of 'samples' interleaved samples for each of its 'channels'. Frames are stored in a single
+// preallocated buffer: writing and reading never allocate.
+type FrameRing struct {
+	lock     sync.Mutex
+	buf      []float32
+	frameLen int // samples * channels
+	layout   Layout
+}
+
+//NewFrameRing creates a ring of 'capacity' frames of 'samples' samples for 'channels' channels.
+//
+// It panics with ErrRange if 'samples' or 'channels' is not positive.
+func NewFrameRing(capacity, samples, channels int) *FrameRing {
+	if samples <= 0 || channels <= 0 {
+		panic(fmt.Errorf("%w: frames of %d samples for %d channels", ErrRange, samples, channels))
+	}
+	frameLen := samples * channels
+	return &FrameRing{
+		buf:      make([]float32, capacity*frameLen),
+		frameLen: frameLen,
+		layout:   NewLayout(capacity),
+	}
+}
+
+//FrameLen returns the number of samples in a frame (all channels included).
+func (f *FrameRing) FrameLen() int {
+	return f.frameLen
+}
+
+//Frames returns the number of frames available for reading.
+func (f *FrameRing) Frames() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.layout.Size
+}
+
+//Free returns the number of frames that can be written.
+func (f *FrameRing) Free() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.layout.Capacity - f.layout.Size
+}
+
+//WriteFrames writes as many whole frames of 'samples' as possible, and returns the number of frames written.
+//
+// Frames are never overwritten before being read: extra frames are not written.
+func (f *FrameRing) WriteFrames(samples []float32) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	n := len(samples) / f.frameLen
+	if free := f.layout.Capacity - f.layout.Size; n > free {
+		n = free
+	}
+	if n == 0 {
+		return 0
+	}
+	first, second := f.layout.Ranges(f.layout.Next(1), n)
+	c := copy(f.buf[first[0]*f.frameLen:first[1]*f.frameLen], samples)
+	copy(f.buf[second[0]*f.frameLen:second[1]*f.frameLen], samples[c:])
+	f.layout = f.layout.Add(n)
+	return n
+}
+
+//ReadFrames reads as many whole frames as possible into 'samples', from the oldest, and returns the number of frames read.
+func (f *FrameRing) ReadFrames(samples []float32) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	n := len(samples) / f.frameLen
+	if n > f.layout.Size {
+		n = f.layout.Size
+	}
+	if n == 0 {
+		return 0
+	}
+	first, second := f.layout.Ranges(f.layout.Tail(), n)
+	c := copy(samples, f.buf[first[0]*f.frameLen:first[1]*f.frameLen])
+	copy(samples[c:], f.buf[second[0]*f.frameLen:second[1]*f.frameLen])
+	f.layout = f.layout.Remove(n)
+	return n
+}
@@ -0,0 +1,51 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFrameRing(t *testing.T) {
+	f := NewFrameRing(3, 2, 2) // frames of 4 samples
+	in := make([]float32, 10)
+	for i := range in {
+		in[i] = float32(i)
+	}
+	if n := f.WriteFrames(in); n != 2 { // the incomplete frame is not written
+		t.Fatalf("Invalid frames written %v, expecting %v", n, 2)
+	}
+	out := make([]float32, 4)
+	if n := f.ReadFrames(out); n != 1 || out[3] != 3 {
+		t.Fatalf("Invalid frames read %v: %v", n, out)
+	}
+	if n := f.WriteFrames(in[:8]); n != 2 { // wraps around
+		t.Fatalf("Invalid frames written %v, expecting %v", n, 2)
+	}
+	if n := f.WriteFrames(in[:4]); n != 0 { // full
+		t.Fatalf("Invalid frames written %v, expecting %v", n, 0)
+	}
+	out = make([]float32, 12)
+	if n := f.ReadFrames(out); n != 3 || out[0] != 4 || out[4] != 0 || out[11] != 7 {
+		t.Fatalf("Invalid frames read %v: %v", n, out)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		f.WriteFrames(in)
+		f.ReadFrames(out)
+	})
+	if allocs != 0 {
+		t.Fatalf("frames should not allocate, got %v allocations", allocs)
+	}
+}
+
+func TestFrameRingInvalid(t *testing.T) {
+	for _, dims := range [][2]int{{0, 2}, {2, 0}, {-1, 2}} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrRange) {
+					t.Fatalf("Frames of %v samples for %v channels should panic with ErrRange, got %v", dims[0], dims[1], err)
+				}
+			}()
+			NewFrameRing(3, dims[0], dims[1])
+		}()
+	}
+}
@@ -0,0 +1,54 @@
+package ringbuffer
+
+//Handle is a reference to a value in a ring, resolvable until the value leaves the ring.
+// It follows the value when the ring is reordered (see Swap).
+//
+// Other subsystems can hold handles to buffered values, without copying them.
+type Handle struct {
+	ring *Ring
+	gen  uint64 // the value's generation (see GetStamped)
+}
+
+//PushHandle pushes 'value' into the ring (see Push), and returns a handle to it.
+//
+// The handle never resolves if the value was discarded (in budget mode, see WithBudget).
+func (b *Ring) PushHandle(value interface{}) Handle {
+	b.lock.Lock()
+	defer b.unlock()
+	seq := b.seq
+	b.push(value)
+	if b.seq == seq {
+		return Handle{}
+	}
+	return Handle{ring: b, gen: b.seq}
+}
+
+//AddHandle adds 'value' to the ring's head (see Add), and returns a handle to it.
+func (b *Ring) AddHandle(value interface{}) (Handle, error) {
+	b.lock.Lock()
+	defer b.unlock()
+	if err := b.add(value); err != nil {
+		return Handle{}, err
+	}
+	return Handle{ring: b, gen: b.seq}, nil
+}
+
+//Get returns the value, and false if it has left the ring.
+func (h Handle) Get() (v interface{}, ok bool) {
+	if h.ring == nil {
+		return nil, false
+	}
+	b := h.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	seq, ok := b.resolve(h.gen)
+	if !ok {
+		return nil, false
+	}
+	return b.buf[b.index(int(b.seq-seq))], true
+}
+
+//Generation returns the value's generation (see GetStamped).
+func (h Handle) Generation() uint64 {
+	return h.gen
+}
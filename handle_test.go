@@ -0,0 +1,37 @@
+package ringbuffer
+
+import "testing"
+
+func TestHandle(t *testing.T) {
+	b := New(2)
+	if _, ok := (Handle{}).Get(); ok {
+		t.Fatalf("The zero handle should not resolve")
+	}
+	if _, ok := b.PushHandle(0).Get(); ok {
+		t.Fatalf("A value pushed into an empty ring should not resolve")
+	}
+	h1, _ := b.AddHandle(1)
+	h2, _ := b.AddHandle(2)
+	if _, err := b.AddHandle(3); err == nil {
+		t.Fatalf("AddHandle should fail on a full ring")
+	}
+	if v, ok := h1.Get(); !ok || v != 1 {
+		t.Fatalf("Handle should resolve to %v, got %v, %v", 1, v, ok)
+	}
+	h3 := b.PushHandle(3)
+	if _, ok := h1.Get(); ok {
+		t.Fatalf("Handle of an evicted value should not resolve")
+	}
+	if v, ok := h2.Get(); !ok || v != 2 {
+		t.Fatalf("Handle should resolve to %v, got %v, %v", 2, v, ok)
+	}
+	if v, ok := h3.Get(); !ok || v != 3 {
+		t.Fatalf("Handle should resolve to %v, got %v, %v", 3, v, ok)
+	}
+
+	b = New(2, WithBudget(4, func(v interface{}) int { return v.(int) }))
+	b.PushHandle(1)
+	if _, ok := b.PushHandle(5).Get(); ok {
+		t.Fatalf("Handle of a discarded value should not resolve")
+	}
+}
@@ -0,0 +1,76 @@
+package ringbuffer
+
+import "sync"
+
+//History is an undo/redo history, bounded by its capacity: the oldest states are forgotten.
+type History struct {
+	lock sync.Mutex
+	ring *Unlocked
+	redo int // number of undone states, at the ring's head
+}
+
+//NewHistory creates a history keeping at most 'capacity' states.
+func NewHistory(capacity int) *History {
+	return &History{ring: NewUnlocked(capacity)}
+}
+
+//Record adds 'state' as the current state.
+//
+// Undone states are discarded: recording after an undo starts a new branch.
+func (h *History) Record(state interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.ring.truncate(h.redo)
+	h.redo = 0
+	h.ring.put(state)
+}
+
+//Current returns the current state, and false if there is none.
+func (h *History) Current() (interface{}, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.redo >= h.ring.size {
+		return nil, false
+	}
+	return h.ring.buf[h.ring.index(h.redo)], true
+}
+
+//Undo goes back to the previous state, and returns it.
+//
+// It returns false if there is no previous state.
+func (h *History) Undo() (interface{}, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.redo+1 >= h.ring.size {
+		return nil, false
+	}
+	h.redo++
+	return h.ring.buf[h.ring.index(h.redo)], true
+}
+
+//Redo goes forward to the state undone last, and returns it.
+//
+// It returns false if there is nothing to redo.
+func (h *History) Redo() (interface{}, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.redo == 0 {
+		return nil, false
+	}
+	h.redo--
+	return h.ring.buf[h.ring.index(h.redo)], true
+}
+
+//CanUndo returns true if there is a previous state.
+func (h *History) CanUndo() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.redo+1 < h.ring.size
+}
+
+//CanRedo returns true if there is an undone state.
+func (h *History) CanRedo() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.redo > 0
+}
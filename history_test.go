@@ -0,0 +1,35 @@
+package ringbuffer
+
+import "testing"
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+	if _, ok := h.Undo(); ok {
+		t.Fatalf("an empty history cannot undo")
+	}
+	for _, s := range []string{"a", "b", "c", "d"} {
+		h.Record(s)
+	}
+	if v, _ := h.Undo(); v != "c" {
+		t.Fatalf("Undo() = %v, expecting %v", v, "c")
+	}
+	if v, _ := h.Undo(); v != "b" {
+		t.Fatalf("Undo() = %v, expecting %v", v, "b")
+	}
+	if _, ok := h.Undo(); ok { // "a" has been forgotten
+		t.Fatalf("the oldest state should have been forgotten")
+	}
+	if v, _ := h.Redo(); v != "c" {
+		t.Fatalf("Redo() = %v, expecting %v", v, "c")
+	}
+	h.Record("e") // drops "d"
+	if h.CanRedo() {
+		t.Fatalf("recording should discard undone states")
+	}
+	if v, _ := h.Current(); v != "e" {
+		t.Fatalf("Current() = %v, expecting %v", v, "e")
+	}
+	if v, _ := h.Undo(); v != "c" {
+		t.Fatalf("Undo() = %v, expecting %v", v, "c")
+	}
+}
@@ -0,0 +1,98 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+//RequestSummary describes an HTTP request handled by a RequestRecorder's middleware.
+type RequestSummary struct {
+	Time    time.Time
+	Method  string
+	Path    string
+	Status  int
+	Latency time.Duration
+	Body    []byte // the body prefix, if any
+}
+
+func (s RequestSummary) String() string {
+	str := fmt.Sprintf("%s %s %s %d %v", s.Time.Format(time.RFC3339Nano), s.Method, s.Path, s.Status, s.Latency)
+	if len(s.Body) > 0 {
+		str += fmt.Sprintf(" %q", s.Body)
+	}
+	return str
+}
+
+//RequestRecorder keeps summaries of the last HTTP requests: a built-in flight recorder for web services.
+//
+// Requests are recorded by the Middleware, and the recorder itself is an http.Handler listing them,
+// from the oldest to the newest, to be mounted as a debug endpoint.
+type RequestRecorder struct {
+	ring       *Ring
+	bodyPrefix int
+}
+
+//NewRequestRecorder creates a recorder keeping the last 'capacity' requests,
+// and the first 'bodyPrefix' bytes of their body.
+//
+// The options configure the underlying ring, WithClock times the requests.
+func NewRequestRecorder(capacity, bodyPrefix int, options ...Option) *RequestRecorder {
+	return &RequestRecorder{ring: New(capacity, options...), bodyPrefix: bodyPrefix}
+}
+
+//Middleware returns a handler recording every request handled by 'next'.
+func (rec *RequestRecorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := RequestSummary{Time: rec.ring.now(), Method: r.Method, Path: r.URL.Path}
+		if rec.bodyPrefix > 0 && r.Body != nil {
+			s.Body, _ = io.ReadAll(io.LimitReader(r.Body, int64(rec.bodyPrefix)))
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(s.Body), r.Body), r.Body}
+		}
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		s.Status = sw.status
+		s.Latency = rec.ring.now().Sub(s.Time)
+
+		rec.ring.lock.Lock()
+		defer rec.ring.unlock()
+		rec.ring.put(s)
+	})
+}
+
+//Requests returns the recorded requests, from the oldest to the newest.
+func (rec *RequestRecorder) Requests() []RequestSummary {
+	values := rec.ring.Values()
+	requests := make([]RequestSummary, len(values))
+	for i, v := range values {
+		requests[i] = v.(RequestSummary)
+	}
+	return requests
+}
+
+//ServeHTTP lists the recorded requests, one per line (see Ring.Dump).
+func (rec *RequestRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	rec.ring.Dump(w)
+}
+
+//statusWriter captures the response status.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+//Unwrap returns the actual writer, for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
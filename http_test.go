@@ -0,0 +1,52 @@
+package ringbuffer
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestRecorder(t *testing.T) {
+	rec := NewRequestRecorder(2, 4)
+	h := rec.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(body)
+	}))
+	for _, path := range []string{"/a", "/missing", "/b"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", path, strings.NewReader("hello world")))
+		if path != "/missing" && w.Body.String() != "hello world" {
+			t.Fatalf("the body should be preserved, got %q", w.Body.String())
+		}
+	}
+	requests := rec.Requests()
+	if len(requests) != 2 || requests[0].Path != "/missing" || requests[0].Status != 404 || string(requests[1].Body) != "hell" {
+		t.Fatalf("Invalid requests %v", requests)
+	}
+
+	w := httptest.NewRecorder()
+	rec.ServeHTTP(w, httptest.NewRequest("GET", "/debug/requests", nil))
+	if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[1], `POST /b 200`) {
+		t.Fatalf("Invalid debug output %q", w.Body.String())
+	}
+}
+
+func TestRequestRecorderClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	rec := NewRequestRecorder(2, 0, WithClock(clock))
+	h := rec.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(50 * time.Millisecond)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/a", nil))
+	if r := rec.Requests()[0]; !r.Time.Equal(start) || r.Latency != 50*time.Millisecond {
+		t.Fatalf("Requests should be timed by the ring's clock, got %v", r)
+	}
+}
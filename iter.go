@@ -0,0 +1,149 @@
+package ringbuffer
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+//SnapshotIter captures the ring's values, and returns an iterator over them, from the oldest to the newest.
+//
+// The iteration never observes concurrent modifications, as it walks a copy taken when SnapshotIter is called.
+// In snapshot mode (see WithSnapshots) the published immutable copy is shared instead of copied.
+func (b *Ring) SnapshotIter() iter.Seq[interface{}] {
+	var values []interface{}
+	if b.snapshots {
+		values = b.snapshot.Load().values
+	} else {
+		values = b.Values()
+	}
+	return func(yield func(interface{}) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+//ValuesDesc returns a copy of the ring's values, from the newest to the oldest (the reverse of Values).
+func (b *Ring) ValuesDesc() []interface{} {
+	if b.snapshots || b.relaxed > 0 {
+		values := b.Values()
+		slices.Reverse(values)
+		return values
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.valuesDesc()
+}
+
+//ValuesDesc returns a copy of the ring's values, from the newest to the oldest (see Ring.ValuesDesc).
+func (b *Unlocked) ValuesDesc() []interface{} { return b.valuesDesc() }
+
+//Backward returns an iterator over the ring's values, from the newest to the oldest.
+//
+// Like SnapshotIter, it walks a copy taken when Backward is called.
+func (b *Ring) Backward() iter.Seq[interface{}] {
+	values := b.ValuesDesc()
+	return func(yield func(interface{}) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+//valuesDesc returns a copy of the ring's values, from the newest to the oldest.
+func (b *ring) valuesDesc() []interface{} {
+	values := make([]interface{}, b.size)
+	if b.size == 0 {
+		return values
+	}
+	older, newer := b.span(b.index(-1), b.size)
+	i := b.size
+	for _, region := range [][]interface{}{older, newer} {
+		for _, v := range region {
+			i--
+			values[i] = v
+		}
+	}
+	return values
+}
+
+//Follow returns an iterator over the ring's values, from the oldest, that then waits for new values,
+// and yields them as they are added, until 'ctx' is done: the equivalent of "tail -f".
+//
+// Values pushed out of the ring before being yielded are skipped.
+func (b *Ring) Follow(ctx context.Context) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		b.lock.RLock()
+		next := b.seq - uint64(b.size) + 1 // sequence number of the next value to yield
+		b.lock.RUnlock()
+		available := func() bool { return b.Sequence() >= next }
+		for ctx.Err() == nil {
+			b.lock.RLock()
+			chunk, seq := b.chunk(next, followChunk)
+			b.lock.RUnlock()
+			for _, v := range chunk {
+				if !yield(v) {
+					return
+				}
+			}
+			next = seq + uint64(len(chunk))
+			if len(chunk) == 0 && b.waiter.Wait(ctx, available) != nil {
+				return
+			}
+		}
+	}
+}
+
+//followChunk is the number of values the iterators copy at once.
+const followChunk = 64
+
+//Every returns an iterator over every 'n'th value of the ring, from the oldest: a thinned view, without copying all the values.
+//
+// Like Chunks, the ring is only locked while a few values are collected.
+func (b *Ring) Every(n int) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		if n < 1 {
+			return
+		}
+		b.lock.RLock()
+		next := b.seq - uint64(b.size) + 1 // sequence number of the next value to yield
+		b.lock.RUnlock()
+		values := make([]interface{}, 0, followChunk)
+		for {
+			values = values[:0]
+			b.lock.RLock()
+			if oldest := b.seq - uint64(b.size) + 1; next < oldest { // skip evicted values, keeping the stride
+				next += (oldest - next + uint64(n) - 1) / uint64(n) * uint64(n)
+			}
+			for ; next <= b.seq && len(values) < cap(values); next += uint64(n) {
+				values = append(values, b.buf[b.index(int(b.seq-next))])
+			}
+			b.lock.RUnlock()
+			if len(values) == 0 {
+				return
+			}
+			for _, v := range values {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+//ForEach calls 'f' on each value of the ring, from the oldest to the newest.
+//
+// Values are copied in small chunks (see Chunks) and 'f' is called without holding the lock:
+// slow callbacks do not stall the writers for the whole traversal.
+func (b *Ring) ForEach(f func(v interface{})) {
+	for chunk := range b.Chunks(followChunk) {
+		for _, v := range chunk {
+			f(v)
+		}
+	}
+}
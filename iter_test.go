@@ -0,0 +1,106 @@
+package ringbuffer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSnapshotIter(t *testing.T) {
+	for _, b := range []*Ring{New(3), New(3, WithSnapshots())} {
+		b.Add(1, 2, 3)
+		var values []interface{}
+		for v := range b.SnapshotIter() {
+			values = append(values, v)
+			b.Push(v.(int) + 10)
+		}
+		if fmt.Sprint(values) != "[1 2 3]" {
+			t.Fatalf("SnapshotIter should not observe concurrent pushes, got %v", values)
+		}
+	}
+}
+
+func TestValuesDesc(t *testing.T) {
+	b := New(3)
+	b.Add(1, 2, 3)
+	b.Push(4) // wraps around
+	if fmt.Sprint(b.ValuesDesc()) != "[4 3 2]" {
+		t.Fatalf("Invalid ValuesDesc %v", b.ValuesDesc())
+	}
+	var values []interface{}
+	for v := range b.Backward() {
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[4 3 2]" {
+		t.Fatalf("Invalid Backward iteration %v", values)
+	}
+	if len(New(3).ValuesDesc()) != 0 || len(NewUnlocked(3).ValuesDesc()) != 0 {
+		t.Fatalf("An empty ring has no value")
+	}
+	s := New(3, WithSnapshots())
+	s.Add(1, 2)
+	if fmt.Sprint(s.ValuesDesc()) != "[2 1]" {
+		t.Fatalf("Invalid ValuesDesc %v", s.ValuesDesc())
+	}
+}
+
+func TestFollow(t *testing.T) {
+	b := New(3)
+	b.Add(1, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.Add(3)
+		b.Push(4)
+	}()
+	var values []interface{}
+	for v := range b.Follow(ctx) {
+		values = append(values, v)
+		if len(values) == 4 {
+			cancel()
+		}
+	}
+	if fmt.Sprint(values) != "[1 2 3 4]" {
+		t.Fatalf("Invalid followed values %v", values)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	b := New(200)
+	for i := 0; i < 250; i++ {
+		if b.Add(i) != nil {
+			b.Push(i)
+		}
+	}
+	var values []interface{}
+	for v := range b.Every(50) {
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[50 100 150 200]" {
+		t.Fatalf("Invalid values %v", values)
+	}
+	values = nil
+	for v := range b.Every(1) {
+		values = append(values, v)
+	}
+	if len(values) != 200 || values[199] != 249 {
+		t.Fatalf("Every(1) should yield all the values, got %v", len(values))
+	}
+}
+
+func TestForEach(t *testing.T) {
+	b := New(100)
+	for i := 0; i < 100; i++ {
+		b.Add(i)
+	}
+	sum := 0
+	b.ForEach(func(v interface{}) {
+		sum += v.(int)
+		b.Remove(0) // would deadlock if the ring was locked
+	})
+	if sum != 4950 {
+		t.Fatalf("Invalid sum %v", sum)
+	}
+}
@@ -0,0 +1,90 @@
+package ringbuffer
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+//LatencyWindow keeps the last recorded durations, to compute their percentiles, e.g. per-request latencies.
+//
+// Durations are stored unboxed (see Layout), Record does not allocate.
+// It is safe for concurrent use.
+type LatencyWindow struct {
+	lock   sync.Mutex
+	buf    []time.Duration
+	layout Layout
+}
+
+//NewLatencyWindow creates a window of the last 'capacity' durations.
+func NewLatencyWindow(capacity int) *LatencyWindow {
+	return &LatencyWindow{buf: make([]time.Duration, capacity), layout: NewLayout(capacity)}
+}
+
+//Record adds 'd' to the window, discarding the oldest duration if the window is full.
+func (w *LatencyWindow) Record(d time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.layout.Capacity == 0 {
+		return
+	}
+	if w.layout.Size < w.layout.Capacity {
+		w.layout = w.layout.Add(1)
+	} else {
+		w.layout = w.layout.Push(1)
+	}
+	w.buf[w.layout.Head] = d
+}
+
+//Since records the time elapsed since 't', e.g.
+//
+//   defer w.Since(time.Now())
+//
+func (w *LatencyWindow) Since(t time.Time) {
+	w.Record(time.Since(t))
+}
+
+//Percentiles returns the nearest-rank percentiles 'ps' (in [0, 100]) of the window's durations, e.g. Percentiles(50, 95, 99).
+//
+// They are all zero if the window is empty.
+func (w *LatencyWindow) Percentiles(ps ...float64) []time.Duration {
+	w.lock.Lock()
+	sorted := slices.Clone(w.buf[:w.layout.Size]) // the order does not matter
+	w.lock.Unlock()
+	slices.Sort(sorted)
+	percentiles := make([]time.Duration, len(ps))
+	if len(sorted) == 0 {
+		return percentiles
+	}
+	for i, p := range ps {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		percentiles[i] = sorted[min(max(rank, 1), len(sorted))-1]
+	}
+	return percentiles
+}
+
+//Max returns the window's longest duration, zero if the window is empty.
+func (w *LatencyWindow) Max() time.Duration {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var m time.Duration
+	for _, d := range w.buf[:w.layout.Size] {
+		m = max(m, d)
+	}
+	return m
+}
+
+//Len returns the number of durations in the window.
+func (w *LatencyWindow) Len() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.layout.Size
+}
+
+//Reset empties the window.
+func (w *LatencyWindow) Reset() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.layout = NewLayout(w.layout.Capacity)
+}
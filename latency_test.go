@@ -0,0 +1,30 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLatencyWindow(t *testing.T) {
+	w := NewLatencyWindow(100)
+	if fmt.Sprint(w.Percentiles(50, 99)) != "[0s 0s]" || w.Max() != 0 {
+		t.Fatalf("An empty window has zero percentiles")
+	}
+	for i := 200; i > 0; i-- {
+		w.Record(time.Duration(i) * time.Millisecond)
+	}
+	if p := w.Percentiles(0, 50, 95, 99, 100); fmt.Sprint(p) != "[1ms 50ms 95ms 99ms 100ms]" {
+		t.Fatalf("Invalid percentiles %v", p)
+	}
+	if w.Max() != 100*time.Millisecond || w.Len() != 100 {
+		t.Fatalf("Invalid max %v", w.Max())
+	}
+	w.Reset()
+	if w.Len() != 0 || w.Max() != 0 {
+		t.Fatalf("Reset should empty the window")
+	}
+	if n := testing.AllocsPerRun(100, func() { w.Record(time.Second) }); n != 0 {
+		t.Fatalf("Record should not allocate, got %v allocations", n)
+	}
+}
@@ -0,0 +1,73 @@
+package ringbuffer
+
+//Layout is the state of a ring buffer, stored in a slice of 'Capacity' slots.
+//
+// It encapsulates the same index math as Ring, so that other storage layers (mmap, shared memory, ...)
+// can lay their values out identically. The zero Layout is not usable, start from NewLayout.
+type Layout struct {
+	Head     int // absolute index of the newest value, -1 when empty
+	Size     int // number of values
+	Capacity int // number of slots
+}
+
+//NewLayout returns the layout of an empty ring of 'capacity' slots.
+func NewLayout(capacity int) Layout {
+	return Layout{Head: -1, Capacity: capacity}
+}
+
+//Index computes the absolute position of the ring's index 'i' (see Index).
+func (l Layout) Index(i int) int {
+	return Index(i, l.Head, l.Size, l.Capacity)
+}
+
+//Next computes the absolute position 'i' slots after the head (see Next).
+func (l Layout) Next(i int) int {
+	return Next(i, l.Head, l.Capacity)
+}
+
+//Tail returns the absolute position of the oldest value, -1 when empty.
+func (l Layout) Tail() int {
+	return l.Index(-1)
+}
+
+//Ranges returns the absolute positions of the 'n' slots from 'start' (wrapping around),
+// as at most two [begin, end) ranges. The second one is empty unless the slots wrap around.
+func (l Layout) Ranges(start, n int) (first, second [2]int) {
+	if start+n <= l.Capacity {
+		return [2]int{start, start + n}, [2]int{0, 0}
+	}
+	return [2]int{start, l.Capacity}, [2]int{0, start + n - l.Capacity}
+}
+
+//Add returns the layout after adding 'n' values at the head (see Ring.Add).
+//
+// The new values are to be written in l.Ranges(l.Next(1), n).
+func (l Layout) Add(n int) Layout {
+	l.Head = l.Next(n)
+	l.Size += n
+	return l
+}
+
+//Push returns the layout after pushing 'n' values (see Ring.Push).
+//
+// Only the last min(n, l.Size) values are to be written, in l.Ranges(l.Next(1), min(n, l.Size)).
+func (l Layout) Push(n int) Layout {
+	if n > l.Size {
+		n = l.Size
+	}
+	l.Head = l.Next(n)
+	return l
+}
+
+//Remove returns the layout after removing the 'n' oldest values (see Ring.Remove).
+func (l Layout) Remove(n int) Layout {
+	if n <= 0 {
+		return l
+	}
+	l.Size -= n
+	if l.Size <= 0 {
+		l.Size = 0
+		l.Head = -1
+	}
+	return l
+}
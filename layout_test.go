@@ -0,0 +1,32 @@
+package ringbuffer
+
+import "testing"
+
+func TestLayout(t *testing.T) {
+	b := New(5)
+	l := NewLayout(5)
+	check := func() {
+		if l.Head != b.head || l.Size != b.size {
+			t.Fatalf("Invalid layout %+v, expecting head=%v size=%v", l, b.head, b.size)
+		}
+	}
+	b.Add(1, 2, 3)
+	l = l.Add(3)
+	check()
+	b.Push(4, 5, 6, 7)
+	l = l.Push(4)
+	check()
+	b.Push(8, 9)
+	l = l.Push(2)
+	check()
+	if b.buf[l.Tail()] != 7 {
+		t.Fatalf("Invalid tail %v, expecting %v", b.buf[l.Tail()], 7)
+	}
+	first, second := l.Ranges(l.Tail(), l.Size)
+	if first != [2]int{0, 3} || second != [2]int{0, 0} {
+		t.Fatalf("Invalid ranges %v %v", first, second)
+	}
+	b.Remove(5)
+	l = l.Remove(5)
+	check()
+}
@@ -0,0 +1,35 @@
+package ringbuffer
+
+//lazyStep is the first allocation size of a lazy ring.
+const lazyStep = 8
+
+//WithLazyAllocation defers the buffer allocation until values are actually added.
+//
+// The buffer is then grown in steps (doubling its size) up to the ring's capacity,
+// so that rings created "just in case" only pay for what they use.
+func WithLazyAllocation() Option {
+	return func(b *ring) {
+		b.lazy = true
+	}
+}
+
+//grow makes room for at least 'n' values in the buffer, 'n' must not exceed the capacity.
+func (b *ring) grow(n int) {
+	if n <= len(b.buf) {
+		return
+	}
+	size := 2 * len(b.buf)
+	if size < lazyStep {
+		size = lazyStep
+	}
+	if size < n {
+		size = n
+	}
+	if b.pow2 {
+		size = powerOfTwo(size)
+	}
+	if size > b.capacity {
+		size = b.capacity
+	}
+	b.resize(size)
+}
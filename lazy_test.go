@@ -0,0 +1,43 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLazyAllocation(t *testing.T) {
+	x := New(100)
+	b := New(100, WithLazyAllocation())
+	if len(b.buf) != 0 {
+		t.Fatalf("lazy ring should not allocate on creation, got %v", len(b.buf))
+	}
+	for i := 0; i < 20; i++ {
+		x.Add(i)
+		b.Add(i)
+	}
+	if len(b.buf) != 32 {
+		t.Fatalf("lazy ring should have grown to %v, got %v", 32, len(b.buf))
+	}
+	b.Push(20, 21, 22)
+	x.Push(20, 21, 22)
+	b.Add(23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35)
+	x.Add(23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35)
+	if !equals(b, x) {
+		t.Errorf("lazy ring differs:\nreal%v\ngold%v\n", b.buf, x.buf)
+	}
+	if b.Capacity() != 100 {
+		t.Fatalf("Invalid capacity %v, expecting %v", b.Capacity(), 100)
+	}
+
+	b.SetCapacity(1000)
+	if len(b.buf) != 64 {
+		t.Fatalf("lazy ring should not grow on SetCapacity, got %v", len(b.buf))
+	}
+	b.SetCapacity(0)
+	if len(b.buf) != b.Size() || !equals(b, x) {
+		t.Errorf("lazy ring differs after shrink:\nreal%v\ngold%v\n", b.buf, x.buf)
+	}
+	if err := b.Add(0); !errors.Is(err, ErrFull) {
+		t.Fatalf("should have failed with ErrFull, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"sync"
+)
+
+//LogWriter is an io.Writer keeping the last complete lines written to it.
+//
+// It is a memory bounded, in-process, log tail: log.SetOutput(io.MultiWriter(os.Stderr, w)).
+type LogWriter struct {
+	lock    sync.Mutex
+	ring    *Ring
+	partial []byte // the last, incomplete, line
+}
+
+//NewLogWriter creates a writer keeping the last 'capacity' lines.
+func NewLogWriter(capacity int) *LogWriter {
+	return &LogWriter{ring: New(capacity)}
+}
+
+//Write splits 'p' into lines, and keeps the complete ones, dropping the oldest lines whole.
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	n := len(p)
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(w.partial) + string(p[:i])
+		w.partial = w.partial[:0]
+		p = p[i+1:]
+		w.ring.lock.Lock()
+		w.ring.put(line)
+		w.ring.unlock()
+	}
+	w.partial = append(w.partial, p...)
+	return n, nil
+}
+
+//Lines returns the kept lines, from the oldest to the newest, without their newline.
+func (w *LogWriter) Lines() []string {
+	values := w.ring.Values()
+	lines := make([]string, len(values))
+	for i, v := range values {
+		lines[i] = v.(string)
+	}
+	return lines
+}
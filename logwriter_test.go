@@ -0,0 +1,22 @@
+package ringbuffer
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLogWriter(t *testing.T) {
+	w := NewLogWriter(2)
+	logger := log.New(w, "", 0)
+	logger.Print("first")
+	logger.Print("second")
+	w.Write([]byte("third\nfou"))
+	if lines := w.Lines(); strings.Join(lines, ",") != "second,third" {
+		t.Fatalf("Invalid lines %v", lines)
+	}
+	w.Write([]byte("rth\n"))
+	if lines := w.Lines(); strings.Join(lines, ",") != "third,fourth" {
+		t.Fatalf("Invalid lines %v", lines)
+	}
+}
@@ -0,0 +1,427 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// File-backed ring layout, all integers are little-endian (whatever the architecture):
+//
+//	header (mappedHeader bytes):
+//	  0  magic "RBMM"
+//	  4  version   uint32
+//	  8  capacity  uint64
+//	  16 record    uint64, the max record length
+//	  24 head      int64, -1 when empty
+//	  32 size      uint64
+//	  40 sequence  uint64
+//	  48 writes    uint64, odd while a write is in progress (see OpenReadOnly), in native byte order
+//	slots (capacity of them, from mappedHeader):
+//	  0  length    uint32
+//	  4  record    'record' bytes
+const (
+	mappedMagic   = "RBMM"
+	mappedVersion = 1
+	mappedHeader  = 64
+)
+
+//IsMappedFile tells whether 'header', the first bytes of a file, starts a mapped ring file (see OpenMapped).
+//
+// It lets tools tell mapped ring files from ring files (see Ring.SaveFile) without reading them whole.
+func IsMappedFile(header []byte) bool {
+	return len(header) >= len(mappedMagic) && string(header[:len(mappedMagic)]) == mappedMagic
+}
+
+//MappedRing is a ring of byte records, stored in a memory-mapped file: it survives the process.
+//
+// Records are limited to a fixed length, so that the file has a fixed size.
+// It is safe for concurrent use, but not by several processes.
+type MappedRing struct {
+	lock   sync.RWMutex
+	file   *os.File
+	m      *mapping
+	layout Layout
+	record int // max record length
+	seq    uint64
+
+	hugePages, reclaim bool // see WithHugePages and WithReclaim
+	readOnly           bool // see OpenReadOnly
+
+	// pages modified since the last Sync
+	page  int
+	dirty []uint64 // bitset
+	pages []int    // dirty pages, unordered
+}
+
+//MappedOption configures a MappedRing when it is opened.
+type MappedOption func(r *MappedRing)
+
+//WithHugePages asks the kernel to back the mapping with (transparent) huge pages, reducing TLB pressure for very large rings.
+//
+// It is an advice, only supported on Linux, ignored elsewhere.
+func WithHugePages() MappedOption {
+	return func(r *MappedRing) {
+		r.hugePages = true
+	}
+}
+
+//WithReclaim releases the memory pages of removed records, reducing the RSS of very large rings.
+//
+// The file is not altered. It is an advice, only supported on Linux, ignored elsewhere.
+func WithReclaim() MappedOption {
+	return func(r *MappedRing) {
+		r.reclaim = true
+	}
+}
+
+//OpenMapped opens the ring file at 'path', creating it if needed with 'capacity' records of at most 'record' bytes.
+//
+// An existing file keeps its own capacity and record length: 'capacity' and 'record' must then match them, or be 0.
+func OpenMapped(path string, capacity, record int, options ...MappedOption) (*MappedRing, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	r := &MappedRing{file: f, page: os.Getpagesize()}
+	for _, option := range options {
+		option(r)
+	}
+	if err := r.open(capacity, record); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if r.hugePages {
+		r.m.hugePages() // an advice, failures are harmless
+	}
+	return r, nil
+}
+
+//errReadOnly is the error returned when writing into a read-only ring.
+var errReadOnly = fmt.Errorf("read-only ring file: %w", errors.ErrUnsupported)
+
+//OpenReadOnly maps the existing ring file at 'path' for reading only.
+//
+// Another process can keep writing into the ring: reads detect concurrent writes, and retry (like a seqlock),
+// so that external inspectors or exporters always read consistent records, without stopping the producer.
+// Push fails, and Remove does nothing.
+func OpenReadOnly(path string, options ...MappedOption) (*MappedRing, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &MappedRing{file: f, page: os.Getpagesize(), readOnly: true}
+	for _, option := range options {
+		option(r)
+	}
+	if err := r.open(0, 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+//open maps the ring file, initializing it if it is empty.
+func (r *MappedRing) open(capacity, record int) error {
+	f := r.file
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 && !r.readOnly {
+		if capacity <= 0 || record <= 0 {
+			return fmt.Errorf("%w: new ring file needs a capacity and a record length", ErrRange)
+		}
+		size := mappedHeader + capacity*(4+record)
+		if err := f.Truncate(int64(size)); err != nil {
+			return err
+		}
+		if r.m, err = mapFile(f, size, true); err != nil {
+			return err
+		}
+		copy(r.m.data, mappedMagic)
+		binary.LittleEndian.PutUint32(r.m.data[4:], mappedVersion)
+		binary.LittleEndian.PutUint64(r.m.data[8:], uint64(capacity))
+		binary.LittleEndian.PutUint64(r.m.data[16:], uint64(record))
+		r.layout, r.record = NewLayout(capacity), record
+		r.writeHeader()
+		return nil
+	}
+
+	if info.Size() < mappedHeader {
+		return fmt.Errorf("%w: truncated ring file header", ErrSnapshot)
+	}
+	if r.m, err = mapFile(f, int(info.Size()), !r.readOnly); err != nil {
+		return err
+	}
+	if err := r.readHeader(capacity, record, int(info.Size())); err != nil {
+		r.m.unmap()
+		return err
+	}
+	return nil
+}
+
+//readHeader loads and checks the file header.
+func (r *MappedRing) readHeader(capacity, record, size int) error {
+	h := r.m.data
+	if string(h[:4]) != mappedMagic {
+		return fmt.Errorf("%w: not a ring file", ErrSnapshot)
+	}
+	if v := binary.LittleEndian.Uint32(h[4:]); v != mappedVersion {
+		return fmt.Errorf("%w: unsupported ring file version %d", ErrSnapshot, v)
+	}
+	c, l := int(binary.LittleEndian.Uint64(h[8:])), int(binary.LittleEndian.Uint64(h[16:]))
+	if (capacity != 0 && capacity != c) || (record != 0 && record != l) {
+		return fmt.Errorf("%w: ring file of %d records of %d bytes, not %d of %d", ErrSnapshot, c, l, capacity, record)
+	}
+	if size != mappedHeader+c*(4+l) {
+		return fmt.Errorf("%w: ring file of %d bytes, expecting %d", ErrSnapshot, size, mappedHeader+c*(4+l))
+	}
+	r.layout.Capacity, r.record = c, l
+	layout, seq, err := r.loadState()
+	if err != nil && r.readOnly { // maybe a write in progress, the state is loaded again by each read
+		return nil
+	}
+	r.layout, r.seq = layout, seq
+	return err
+}
+
+//loadState reads the ring's state in the file header.
+func (r *MappedRing) loadState() (l Layout, seq uint64, err error) {
+	h := r.m.data
+	l = Layout{
+		Head:     int(int64(binary.LittleEndian.Uint64(h[24:]))),
+		Size:     int(binary.LittleEndian.Uint64(h[32:])),
+		Capacity: r.layout.Capacity,
+	}
+	if l.Size > l.Capacity || l.Head >= l.Capacity || (l.Head < 0) != (l.Size == 0) {
+		return l, 0, fmt.Errorf("%w: corrupted ring file header", ErrSnapshot)
+	}
+	return l, binary.LittleEndian.Uint64(h[40:]), nil
+}
+
+//writes returns the header's write counter.
+func (r *MappedRing) writes() *uint64 {
+	return (*uint64)(unsafe.Pointer(&r.m.data[48]))
+}
+
+//write runs 'f', a modification of the file, flagged in the write counter for read-only readers.
+func (r *MappedRing) write(f func()) {
+	atomic.AddUint64(r.writes(), 1)
+	f()
+	r.writeHeader()
+	atomic.AddUint64(r.writes(), 1)
+}
+
+//read runs 'f' with a consistent state of the ring.
+//
+// Read-only rings load the state from the file, and run 'f' again if a write happened meanwhile (like a seqlock).
+func (r *MappedRing) read(f func(l Layout, seq uint64)) {
+	if !r.readOnly {
+		f(r.layout, r.seq)
+		return
+	}
+	for {
+		w := atomic.LoadUint64(r.writes())
+		if l, seq, err := r.loadState(); w%2 == 0 && err == nil {
+			f(l, seq)
+			if atomic.LoadUint64(r.writes()) == w {
+				return
+			}
+		}
+		runtime.Gosched()
+	}
+}
+
+//writeHeader stores the ring's state in the file header.
+func (r *MappedRing) writeHeader() {
+	h := r.m.data
+	r.touch(0, mappedHeader)
+	binary.LittleEndian.PutUint64(h[24:], uint64(int64(r.layout.Head)))
+	binary.LittleEndian.PutUint64(h[32:], uint64(r.layout.Size))
+	binary.LittleEndian.PutUint64(h[40:], r.seq)
+}
+
+//slot returns the slot at the absolute position 'i'.
+func (r *MappedRing) slot(i int) []byte {
+	n := 4 + r.record
+	return r.m.data[mappedHeader+i*n : mappedHeader+(i+1)*n]
+}
+
+//Push adds 'p' to the ring's head, discarding the oldest record if the ring is full.
+//
+// It fails with ErrRange if 'p' is longer than the record length.
+func (r *MappedRing) Push(p []byte) error {
+	if len(p) > r.record {
+		return fmt.Errorf("%w: record of %d bytes, max is %d", ErrRange, len(p), r.record)
+	}
+	if r.readOnly {
+		return errReadOnly
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.write(func() {
+		if r.layout.Size < r.layout.Capacity {
+			r.layout = r.layout.Add(1)
+		} else {
+			r.layout = r.layout.Push(1)
+		}
+		s := r.slot(r.layout.Head)
+		r.touch(mappedHeader+r.layout.Head*len(s), 4+len(p))
+		binary.LittleEndian.PutUint32(s, uint32(len(p)))
+		copy(s[4:], p)
+		r.seq++
+	})
+	return nil
+}
+
+//Remove removes the 'count' oldest records.
+//
+// It does nothing on a read-only ring.
+func (r *MappedRing) Remove(count int) {
+	if r.readOnly {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if count > r.layout.Size {
+		count = r.layout.Size
+	}
+	if r.reclaim && count > 0 {
+		n := 4 + r.record
+		first, second := r.layout.Ranges(r.layout.Tail(), count)
+		for _, s := range [][2]int{first, second} {
+			r.m.release(mappedHeader+s[0]*n, (s[1]-s[0])*n)
+		}
+	}
+	r.write(func() {
+		r.layout = r.layout.Remove(count)
+	})
+}
+
+//Get returns a copy of the record at index 'i' (see Ring.Get).
+func (r *MappedRing) Get(i int) ([]byte, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var p []byte
+	r.read(func(l Layout, seq uint64) {
+		if l.Size > 0 {
+			p = r.get(l.Index(i))
+		}
+	})
+	if p == nil {
+		return nil, ErrEmpty
+	}
+	return p, nil
+}
+
+//Values returns a copy of the records, from the oldest to the newest.
+func (r *MappedRing) Values() [][]byte {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var values [][]byte
+	r.read(func(l Layout, seq uint64) {
+		values = make([][]byte, l.Size)
+		for i := range values {
+			values[i] = r.get(l.Index(l.Size - 1 - i))
+		}
+	})
+	return values
+}
+
+//get returns a copy of the record in the slot 'i'.
+func (r *MappedRing) get(i int) []byte {
+	s := r.slot(i)
+	n := int(binary.LittleEndian.Uint32(s))
+	if n > r.record { // corrupted length
+		n = r.record
+	}
+	return append([]byte(nil), s[4:4+n]...)
+}
+
+//Size returns the number of records.
+func (r *MappedRing) Size() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var size int
+	r.read(func(l Layout, seq uint64) { size = l.Size })
+	return size
+}
+
+//Capacity returns the max number of records.
+func (r *MappedRing) Capacity() int {
+	return r.layout.Capacity
+}
+
+//Sequence returns the sequence number of the newest record (see Ring.Sequence).
+func (r *MappedRing) Sequence() uint64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var sequence uint64
+	r.read(func(l Layout, seq uint64) { sequence = seq })
+	return sequence
+}
+
+//Sync flushes the mapping to the file, so that the records survive a system crash too.
+//
+// Only the pages modified since the last Sync are flushed: its latency depends on the activity, not on the capacity.
+func (r *MappedRing) Sync() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.sync()
+}
+
+//touch marks the pages of the 'n' bytes from 'off' as dirty.
+func (r *MappedRing) touch(off, n int) {
+	if r.dirty == nil {
+		r.dirty = make([]uint64, len(r.m.data)/r.page/64+1)
+	}
+	for p := off / r.page; p <= (off+n-1)/r.page; p++ {
+		if r.dirty[p/64]&(1<<(p%64)) == 0 {
+			r.dirty[p/64] |= 1 << (p % 64)
+			r.pages = append(r.pages, p)
+		}
+	}
+}
+
+//sync flushes the dirty pages, coalescing consecutive ones.
+func (r *MappedRing) sync() error {
+	slices.Sort(r.pages)
+	var err error
+	for i := 0; i < len(r.pages); {
+		j := i + 1
+		for j < len(r.pages) && r.pages[j] == r.pages[j-1]+1 {
+			j++
+		}
+		off, end := r.pages[i]*r.page, min((r.pages[j-1]+1)*r.page, len(r.m.data))
+		if ferr := r.m.flush(off, end-off); err == nil {
+			err = ferr
+		}
+		i = j
+	}
+	for _, p := range r.pages {
+		r.dirty[p/64] &^= 1 << (p % 64)
+	}
+	r.pages = r.pages[:0]
+	return err
+}
+
+//Close syncs and unmaps the file, and closes it.
+func (r *MappedRing) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := r.sync()
+	if uerr := r.m.unmap(); err == nil {
+		err = uerr
+	}
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
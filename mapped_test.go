@@ -0,0 +1,124 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMapped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	if _, err := OpenMapped(path, 0, 0); !errors.Is(err, ErrRange) {
+		t.Fatalf("OpenMapped should need a capacity, got %v", err)
+	}
+	r, err := OpenMapped(path, 3, 4)
+	if err != nil {
+		t.Fatalf("OpenMapped failed: %v", err)
+	}
+	for _, s := range []string{"a", "bb", "ccc", "dddd"} {
+		r.Push([]byte(s))
+	}
+	if err := r.Push([]byte("eeeee")); !errors.Is(err, ErrRange) {
+		t.Fatalf("Push should reject records too long, got %v", err)
+	}
+	if v, _ := r.Get(0); string(v) != "dddd" {
+		t.Fatalf("Get(0) should return %q, got %q", "dddd", v)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := OpenMapped(path, 5, 4); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("OpenMapped should reject another capacity, got %v", err)
+	}
+	r, err = OpenMapped(path, 0, 0)
+	if err != nil {
+		t.Fatalf("OpenMapped failed: %v", err)
+	}
+	defer r.Close()
+	if fmt.Sprintf("%s", r.Values()) != "[bb ccc dddd]" || r.Capacity() != 3 || r.Sequence() != 4 {
+		t.Fatalf("Invalid reopened ring %s, %v, %v", r.Values(), r.Capacity(), r.Sequence())
+	}
+	r.Remove(2)
+	if r.Size() != 1 {
+		t.Fatalf("Invalid size %v", r.Size())
+	}
+	if err := r.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	os.WriteFile(path, []byte("not a ring file, but long enough for a header.........................."), 0o644)
+	if _, err := OpenMapped(path, 0, 0); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("OpenMapped should reject invalid files, got %v", err)
+	}
+}
+
+func TestMappedReclaim(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	r, err := OpenMapped(path, 4, 3*os.Getpagesize(), WithHugePages(), WithReclaim())
+	if err != nil {
+		t.Fatalf("OpenMapped failed: %v", err)
+	}
+	defer r.Close()
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		r.Push([]byte(s))
+	}
+	r.Remove(2)
+	r.Push([]byte("f"))
+	if fmt.Sprintf("%s", r.Values()) != "[d e f]" {
+		t.Fatalf("Invalid values %s", r.Values())
+	}
+}
+
+func TestMappedSync(t *testing.T) {
+	page := os.Getpagesize()
+	r, err := OpenMapped(filepath.Join(t.TempDir(), "ring"), 8, page)
+	if err != nil {
+		t.Fatalf("OpenMapped failed: %v", err)
+	}
+	defer r.Close()
+	r.Sync()
+	r.Push([]byte("a"))
+	r.Push([]byte("b"))
+	// slots span a page and 4 bytes: only the header page (holding "a") and the next one (holding "b") are written
+	if fmt.Sprint(r.pages) != "[0 1]" {
+		t.Fatalf("Invalid dirty pages %v", r.pages)
+	}
+	if err := r.Sync(); err != nil || len(r.pages) != 0 {
+		t.Fatalf("Sync should clear dirty pages, got %v, %v", r.pages, err)
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	w, err := OpenMapped(path, 3, 4)
+	if err != nil {
+		t.Fatalf("OpenMapped failed: %v", err)
+	}
+	defer w.Close()
+	w.Push([]byte("a"))
+	if header, _ := os.ReadFile(path); !IsMappedFile(header[:4]) || IsMappedFile([]byte("RBUF\x01")) || IsMappedFile(nil) {
+		t.Fatalf("IsMappedFile should only recognize mapped ring files")
+	}
+	r, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly failed: %v", err)
+	}
+	defer r.Close()
+	w.Push([]byte("b"))
+	if fmt.Sprintf("%s", r.Values()) != "[a b]" || r.Sequence() != 2 || r.Size() != 2 {
+		t.Fatalf("Read-only ring should follow the writer, got %s", r.Values())
+	}
+	if v, _ := r.Get(0); string(v) != "b" {
+		t.Fatalf("Get(0) should return %q, got %q", "b", v)
+	}
+	if err := r.Push([]byte("c")); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("Push should fail on a read-only ring, got %v", err)
+	}
+	r.Remove(1)
+	if w.Size() != 2 {
+		t.Fatalf("Remove should do nothing on a read-only ring")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build unix && !(linux || darwin || freebsd || openbsd || dragonfly)
+
+package ringbuffer
+
+//flush writes the mapping back to the file: without msync, the whole file is synced.
+func (m *mapping) flush(off, n int) error {
+	return m.file.Sync()
+}
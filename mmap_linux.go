@@ -0,0 +1,23 @@
+package ringbuffer
+
+import (
+	"os"
+	"syscall"
+)
+
+//hugePages advises the kernel to back the mapping with huge pages.
+func (m *mapping) hugePages() error {
+	return os.NewSyscallError("madvise", syscall.Madvise(m.data, syscall.MADV_HUGEPAGE))
+}
+
+//release advises the kernel that the 'n' bytes from 'off' are not needed anymore, it may drop their pages.
+//
+// Only the pages entirely within the range are released, the file keeps its content.
+func (m *mapping) release(off, n int) error {
+	page := os.Getpagesize()
+	start, end := (off+page-1)/page*page, (off+n)/page*page
+	if start >= end {
+		return nil
+	}
+	return os.NewSyscallError("madvise", syscall.Madvise(m.data[start:end], syscall.MADV_DONTNEED))
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin || freebsd || openbsd || dragonfly
+
+package ringbuffer
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+//flush writes the 'n' bytes from 'off' back to the file, synchronously.
+func (m *mapping) flush(off, n int) error {
+	page := os.Getpagesize()
+	start := off / page * page // msync needs an aligned address
+	if n += off - start; n == 0 {
+		return nil
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(unsafe.Pointer(&m.data[start])), uintptr(n), syscall.MS_SYNC)
+	if errno != 0 {
+		return os.NewSyscallError("msync", errno)
+	}
+	return nil
+}
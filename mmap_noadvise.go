@@ -0,0 +1,9 @@
+//go:build !linux
+
+package ringbuffer
+
+//hugePages does nothing: huge pages advice is only supported on Linux.
+func (m *mapping) hugePages() error { return nil }
+
+//release does nothing: page release advice is only supported on Linux.
+func (m *mapping) release(off, n int) error { return nil }
@@ -0,0 +1,21 @@
+//go:build !unix && !windows
+
+package ringbuffer
+
+import (
+	"errors"
+	"os"
+)
+
+//mapping is a file mapped in memory, not supported on this platform.
+type mapping struct {
+	data []byte
+}
+
+func mapFile(f *os.File, size int, writable bool) (*mapping, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (m *mapping) unmap() error { return errors.ErrUnsupported }
+
+func (m *mapping) flush(off, n int) error { return errors.ErrUnsupported }
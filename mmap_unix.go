@@ -0,0 +1,32 @@
+//go:build unix
+
+package ringbuffer
+
+import (
+	"os"
+	"syscall"
+)
+
+//mapping is a file mapped in memory.
+type mapping struct {
+	data []byte
+	file *os.File
+}
+
+//mapFile maps the 'size' first bytes of 'f', shared, and writable or not.
+func mapFile(f *os.File, size int, writable bool) (*mapping, error) {
+	prot := syscall.PROT_READ
+	if writable {
+		prot |= syscall.PROT_WRITE
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, prot, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, os.NewSyscallError("mmap", err)
+	}
+	return &mapping{data: data, file: f}, nil
+}
+
+//unmap releases the mapping.
+func (m *mapping) unmap() error {
+	return os.NewSyscallError("munmap", syscall.Munmap(m.data))
+}
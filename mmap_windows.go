@@ -0,0 +1,55 @@
+//go:build windows
+
+package ringbuffer
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+//mapping is a file mapped in memory.
+type mapping struct {
+	data   []byte
+	file   *os.File
+	handle syscall.Handle // the file mapping object
+}
+
+//mapFile maps the 'size' first bytes of 'f', shared, and writable or not.
+func mapFile(f *os.File, size int, writable bool) (*mapping, error) {
+	protect, access := uint32(syscall.PAGE_READONLY), uint32(syscall.FILE_MAP_READ)
+	if writable {
+		protect, access = syscall.PAGE_READWRITE, syscall.FILE_MAP_WRITE
+	}
+	h, err := syscall.CreateFileMapping(syscall.Handle(f.Fd()), nil, protect, uint32(uint64(size)>>32), uint32(size), nil)
+	if err != nil {
+		return nil, os.NewSyscallError("CreateFileMapping", err)
+	}
+	addr, err := syscall.MapViewOfFile(h, access, 0, 0, uintptr(size))
+	if err != nil {
+		syscall.CloseHandle(h)
+		return nil, os.NewSyscallError("MapViewOfFile", err)
+	}
+	ptr := *(*unsafe.Pointer)(unsafe.Pointer(&addr)) // the view is not managed by Go
+	return &mapping{data: unsafe.Slice((*byte)(ptr), size), file: f, handle: h}, nil
+}
+
+//unmap releases the mapping.
+func (m *mapping) unmap() error {
+	err := syscall.UnmapViewOfFile(uintptr(unsafe.Pointer(&m.data[0])))
+	if cerr := syscall.CloseHandle(m.handle); err == nil {
+		err = cerr
+	}
+	return os.NewSyscallError("UnmapViewOfFile", err)
+}
+
+//flush writes the 'n' bytes from 'off' back to the file, synchronously.
+func (m *mapping) flush(off, n int) error {
+	if n == 0 {
+		return nil
+	}
+	if err := syscall.FlushViewOfFile(uintptr(unsafe.Pointer(&m.data[off])), uintptr(n)); err != nil {
+		return os.NewSyscallError("FlushViewOfFile", err)
+	}
+	return m.file.Sync()
+}
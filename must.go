@@ -0,0 +1,37 @@
+package ringbuffer
+
+//MustGet is like Get but panics if the ring is empty.
+//
+// It is meant for tests and initialization code, where an empty ring is a programmer error.
+func (b *Ring) MustGet(i int) interface{} {
+	v, err := b.Get(i)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//MustAdd is like Add but panics if the values do not fit in the ring.
+//
+// It is meant for tests and initialization code, where a full ring is a programmer error.
+func (b *Ring) MustAdd(values ...interface{}) {
+	if err := b.Add(values...); err != nil {
+		panic(err)
+	}
+}
+
+//MustGet is like Get but panics if the ring is empty (see Ring.MustGet).
+func (b *Unlocked) MustGet(i int) interface{} {
+	v, err := b.Get(i)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+//MustAdd is like Add but panics if the values do not fit in the ring (see Ring.MustAdd).
+func (b *Unlocked) MustAdd(values ...interface{}) {
+	if err := b.Add(values...); err != nil {
+		panic(err)
+	}
+}
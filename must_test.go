@@ -0,0 +1,26 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMust(t *testing.T) {
+	b := New(2)
+	b.MustAdd(1, 2)
+	if b.MustGet(0) != 2 {
+		t.Fatalf("MustGet(0) = %v, expecting %v", b.MustGet(0), 2)
+	}
+	assertPanics(t, ErrFull, func() { b.MustAdd(3) })
+	assertPanics(t, ErrEmpty, func() { New(1).MustGet(0) })
+}
+
+func assertPanics(t *testing.T, target error, f func()) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, target) {
+			t.Fatalf("should have panicked with %v, got %v", target, err)
+		}
+	}()
+	f()
+}
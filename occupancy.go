@@ -0,0 +1,60 @@
+package ringbuffer
+
+import "time"
+
+//Utilization returns the ring's fill level, from 0 (empty) to 1 (full).
+//
+// It does not lock the ring.
+func (b *Ring) Utilization() float64 {
+	return utilization(int(b.stats.size.Load()), int(b.stats.capacity.Load()))
+}
+
+//utilization returns the fill level of 'size' values out of 'capacity'.
+func utilization(size, capacity int) float64 {
+	if capacity <= 0 {
+		return 1
+	}
+	return min(float64(size)/float64(capacity), 1)
+}
+
+//WithOccupancy makes the ring track its fill level (see Utilization) over the last 'n' intervals of 'width',
+//see Occupancy.
+//
+// The fill level is sampled after each modification, using the ring's clock (see WithClock).
+func WithOccupancy(n int, width time.Duration) Option {
+	return func(b *ring) {
+		b.occupancy = &BucketRing{ring: NewUnlocked(n), width: width}
+	}
+}
+
+//initOccupancy starts tracking the occupancy, once the ring's clock is known (see WithOccupancy).
+func (b *ring) initOccupancy() {
+	if b.occupancy == nil {
+		return
+	}
+	b.occupancy.ring.clock = b.clock
+	b.occupancy.current = b.occupancy.empty(b.now().Truncate(b.occupancy.width))
+}
+
+//Occupancy returns the mean and the max fill level of the ring over the tracked intervals (see WithOccupancy),
+//to tune its capacity based on measured fill levels.
+//
+// The mean is over the modifications, not over time. Without any modification during the intervals,
+// both are the current fill level. It returns zeros if the occupancy is not tracked.
+func (b *Ring) Occupancy() (mean, peak float64) {
+	if b.occupancy == nil {
+		return 0, 0
+	}
+	count, sum := 0, 0.
+	for _, bucket := range b.occupancy.Buckets() {
+		if bucket.Count > 0 {
+			count, sum = count+bucket.Count, sum+bucket.Sum
+			peak = max(peak, bucket.Max)
+		}
+	}
+	if count == 0 {
+		u := b.Utilization()
+		return u, u
+	}
+	return sum / float64(count), peak
+}
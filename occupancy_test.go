@@ -0,0 +1,45 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUtilization(t *testing.T) {
+	b := New(4)
+	if b.Utilization() != 0 {
+		t.Fatalf("An empty ring has a zero utilization, got %v", b.Utilization())
+	}
+	b.Add(1)
+	if b.Utilization() != 0.25 {
+		t.Fatalf("Invalid utilization %v", b.Utilization())
+	}
+	if New(0).Utilization() != 1 || NewUnlocked(0).Utilization() != 1 {
+		t.Fatalf("A ring without capacity is always full")
+	}
+	if mean, peak := b.Occupancy(); mean != 0 || peak != 0 {
+		t.Fatalf("The occupancy is not tracked, got %v, %v", mean, peak)
+	}
+}
+
+func TestOccupancy(t *testing.T) {
+	c := NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := New(4, WithOccupancy(2, time.Second), WithClock(c))
+	b.Add(1, 2)
+	b.Add(3, 4)
+	c.Advance(time.Second)
+	b.Remove(4)
+	// samples: 0 (New), 0.5, 1, 0
+	if mean, peak := b.Occupancy(); mean != 0.375 || peak != 1 {
+		t.Fatalf("Invalid occupancy %v, %v", mean, peak)
+	}
+	c.Advance(time.Hour)
+	b.Add(1)
+	if mean, peak := b.Occupancy(); mean != 0.25 || peak != 0.25 {
+		t.Fatalf("Old fill levels should be forgotten, got %v, %v", mean, peak)
+	}
+	c.Advance(time.Hour)
+	if mean, peak := b.Occupancy(); mean != 0.25 || peak != 0.25 {
+		t.Fatalf("Without modifications, the occupancy should be the current fill level, got %v, %v", mean, peak)
+	}
+}
@@ -0,0 +1,21 @@
+package ringbuffer
+
+//WithOldestFirst makes index 0 designate the oldest value, instead of the newest.
+//
+// Get(0) then returns the oldest value, and Get(-1) the newest. It applies to every index based method:
+// Get, GetOrDefault, MustGet, MultiGet, GetRange (which then returns values from the oldest), GetStamped,
+// Slice, Cursor and Window. Values and the iterators are not affected: they always go from the oldest to the newest,
+// and neither are Oldest and Newest.
+func WithOldestFirst() Option {
+	return func(b *ring) {
+		b.oldestFirst = true
+	}
+}
+
+//logical converts the index 'i', as seen by the user, to the index used internally (0 being the newest).
+func (b *ring) logical(i int) int {
+	if b.oldestFirst {
+		return -1 - i
+	}
+	return i
+}
@@ -0,0 +1,44 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOldestFirst(t *testing.T) {
+	b := New(4, WithOldestFirst())
+	b.Add(1, 2, 3, 4)
+	b.Push(5) // wraps around
+	if v, _ := b.Get(0); v != 2 {
+		t.Fatalf("Get(0) should return the oldest %v, got %v", 2, v)
+	}
+	if v, _ := b.Get(-1); v != 5 {
+		t.Fatalf("Get(-1) should return the newest %v, got %v", 5, v)
+	}
+	if values, _ := b.MultiGet(0, 1); fmt.Sprint(values) != "[2 3]" {
+		t.Fatalf("Invalid MultiGet %v", values)
+	}
+	if values, err := b.GetRange(1, 3); err != nil || fmt.Sprint(values) != "[3 4 5]" {
+		t.Fatalf("Invalid GetRange %v, %v", values, err)
+	}
+	if _, gen, _ := b.GetStamped(0); gen != 2 {
+		t.Fatalf("GetStamped(0) should return the oldest generation %v, got %v", 2, gen)
+	}
+	c, _ := b.Cursor(1)
+	if i, _ := c.Index(); i != 1 {
+		t.Fatalf("Invalid cursor index %v", i)
+	}
+	w := b.Window(1, 2)
+	if v, _ := w.Get(0); v != 3 || fmt.Sprint(w.Values()) != "[3 4]" {
+		t.Fatalf("Invalid window %v", w.Values())
+	}
+	if fmt.Sprint(b.Values()) != "[2 3 4 5]" {
+		t.Fatalf("Values should not be affected, got %v", b.Values())
+	}
+
+	s := New(3, WithOldestFirst(), WithSnapshots())
+	s.Add(1, 2)
+	if v, _ := s.Get(0); v != 1 {
+		t.Fatalf("Get(0) should return the oldest %v, got %v", 1, v)
+	}
+}
@@ -0,0 +1,39 @@
+package ringbuffer
+
+import "fmt"
+
+//OverflowError is the error returned by Add when the values do not fit in the ring.
+//
+// It matches ErrFull with errors.Is.
+type OverflowError struct {
+	Requested int  // the number of values (or bytes) to add
+	Size      int  // the ring's size, including reserved slots (or bytes) when the values were added
+	Capacity  int  // the ring's capacity (or budget)
+	Budget    bool // true if the above are bytes, the budget being exhausted (see WithBudget)
+}
+
+func (e *OverflowError) Error() string {
+	unit := "values"
+	if e.Budget {
+		unit = "bytes"
+	}
+	return fmt.Sprintf("%v: cannot add %d %s to %d, over capacity %d by %d", ErrFull, e.Requested, unit, e.Size, e.Capacity, e.Over())
+}
+
+//Is reports whether 'target' is ErrFull.
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrFull
+}
+
+//Over returns how far over the capacity (or the budget) the values are.
+func (e *OverflowError) Over() int {
+	return e.Size + e.Requested - e.Capacity
+}
+
+//overflow builds the error explaining why 'values' cannot be added.
+func (b *ring) overflow(values []interface{}) error {
+	if b.size+b.reserved+len(values) > b.capacity {
+		return &OverflowError{Requested: len(values), Size: b.size + b.reserved, Capacity: b.capacity}
+	}
+	return &OverflowError{Requested: b.bytesOf(values), Size: b.bytes, Capacity: b.budget, Budget: true}
+}
@@ -0,0 +1,26 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOverflowError(t *testing.T) {
+	b := New(3)
+	b.Add(1)
+	err := b.Add(1, 2, 3)
+	var overflow *OverflowError
+	if !errors.As(err, &overflow) || !errors.Is(err, ErrFull) {
+		t.Fatalf("should have failed with an OverflowError, got %v", err)
+	}
+	if overflow.Requested != 3 || overflow.Size != 1 || overflow.Capacity != 3 || overflow.Over() != 1 {
+		t.Fatalf("Invalid overflow %#v", overflow)
+	}
+
+	b = New(3, WithBudget(5, func(v interface{}) int { return v.(int) }))
+	b.Add(4)
+	err = b.Add(2)
+	if !errors.As(err, &overflow) || !overflow.Budget || overflow.Over() != 1 {
+		t.Fatalf("should have failed with a budget OverflowError, got %v", err)
+	}
+}
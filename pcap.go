@@ -0,0 +1,83 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+//Link types commonly used with PacketRing (see https://www.tcpdump.org/linktypes.html).
+const (
+	LinkTypeEthernet = 1
+	LinkTypeRaw      = 101
+)
+
+//Packet is a captured network packet.
+type Packet struct {
+	Time    time.Time
+	Data    []byte // possibly truncated to the snapshot length
+	OrigLen int    // the packet's length on the wire
+}
+
+//PacketRing keeps the last captured packets, and exports them in the pcap format.
+type PacketRing struct {
+	ring     *Ring
+	snapLen  int
+	linkType uint32
+}
+
+//NewPacketRing creates a ring of 'capacity' packets, truncated to 'snapLen' bytes, captured on a 'linkType' link.
+//
+// The options configure the underlying ring, WithClock timestamps the captured packets.
+func NewPacketRing(capacity, snapLen int, linkType uint32, options ...Option) *PacketRing {
+	return &PacketRing{ring: New(capacity, options...), snapLen: snapLen, linkType: linkType}
+}
+
+//Capture adds a copy of 'data', timestamped by the ring's clock, discarding the oldest packet if the ring is full.
+func (p *PacketRing) Capture(data []byte) {
+	p.Add(Packet{Time: p.ring.now(), Data: append([]byte(nil), data...), OrigLen: len(data)})
+}
+
+//Add adds 'pkt', discarding the oldest packet if the ring is full.
+func (p *PacketRing) Add(pkt Packet) {
+	if len(pkt.Data) > p.snapLen {
+		pkt.Data = pkt.Data[:p.snapLen]
+	}
+	p.ring.lock.Lock()
+	defer p.ring.unlock()
+	p.ring.put(pkt)
+}
+
+//Packets returns the captured packets, from the oldest to the newest.
+func (p *PacketRing) Packets() []Packet {
+	values := p.ring.Values()
+	packets := make([]Packet, len(values))
+	for i, v := range values {
+		packets[i] = v.(Packet)
+	}
+	return packets
+}
+
+//WritePCAP writes the captured packets to 'w' in the (libpcap) pcap format, readable by tcpdump or Wireshark.
+func (p *PacketRing) WritePCAP(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	le := binary.LittleEndian
+	header := make([]byte, 24)
+	le.PutUint32(header[0:], 0xa1b2c3d4) // magic, microsecond timestamps
+	le.PutUint16(header[4:], 2)          // version 2.4
+	le.PutUint16(header[6:], 4)
+	le.PutUint32(header[16:], uint32(p.snapLen))
+	le.PutUint32(header[20:], p.linkType)
+	bw.Write(header)
+	record := make([]byte, 16)
+	for _, pkt := range p.Packets() {
+		le.PutUint32(record[0:], uint32(pkt.Time.Unix()))
+		le.PutUint32(record[4:], uint32(pkt.Time.Nanosecond()/1000))
+		le.PutUint32(record[8:], uint32(len(pkt.Data)))
+		le.PutUint32(record[12:], uint32(pkt.OrigLen))
+		bw.Write(record)
+		bw.Write(pkt.Data)
+	}
+	return bw.Flush()
+}
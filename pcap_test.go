@@ -0,0 +1,45 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestPacketRing(t *testing.T) {
+	p := NewPacketRing(2, 4, LinkTypeRaw)
+	t0 := time.Unix(1400000000, 123456000)
+	p.Add(Packet{Time: t0, Data: []byte{1}, OrigLen: 1})
+	p.Add(Packet{Time: t0, Data: []byte{2, 2}, OrigLen: 2})
+	p.Add(Packet{Time: t0.Add(time.Second), Data: []byte{3, 3, 3, 3, 3, 3}, OrigLen: 6})
+	var out bytes.Buffer
+	if err := p.WritePCAP(&out); err != nil {
+		t.Fatal(err.Error())
+	}
+	data := out.Bytes()
+	if len(data) != 24+16+2+16+4 {
+		t.Fatalf("Invalid pcap length %v", len(data))
+	}
+	le := binary.LittleEndian
+	if le.Uint32(data) != 0xa1b2c3d4 || le.Uint32(data[20:]) != LinkTypeRaw {
+		t.Fatalf("Invalid pcap header % x", data[:24])
+	}
+	record := data[24+16+2:]
+	if le.Uint32(record) != 1400000001 || le.Uint32(record[4:]) != 123456 || le.Uint32(record[8:]) != 4 || le.Uint32(record[12:]) != 6 {
+		t.Fatalf("Invalid pcap record % x", record[:16])
+	}
+}
+
+func TestPacketRingClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	p := NewPacketRing(3, 64, LinkTypeRaw, WithClock(clock))
+	p.Capture([]byte{1})
+	clock.Advance(time.Second)
+	p.Capture([]byte{2})
+	packets := p.Packets()
+	if !packets[0].Time.Equal(start) || !packets[1].Time.Equal(start.Add(time.Second)) {
+		t.Fatalf("Capture should timestamp the packets by the ring's clock, got %v and %v", packets[0].Time, packets[1].Time)
+	}
+}
@@ -0,0 +1,64 @@
+package ringbuffer
+
+import "sync"
+
+//Pool is a set of reusable rings of a given capacity.
+//
+// It wraps a sync.Pool, so that servers allocating a ring per request do not generate GC pressure.
+type Pool struct {
+	capacity int
+	pool     sync.Pool
+}
+
+//NewPool creates a pool of rings created with 'capacity' and 'options'.
+func NewPool(capacity int, options ...Option) *Pool {
+	first := New(capacity, options...)
+	p := &Pool{capacity: first.capacity} // the options may change the capacity, e.g. WithPowerOfTwo
+	p.pool.New = func() interface{} { return New(capacity, options...) }
+	p.pool.Put(first)
+	return p
+}
+
+//Get returns a fresh ring from the pool, or a new one.
+//
+// A reused ring is empty, and its statistics, sequence number, tags and watches are reset.
+func (p *Pool) Get() *Ring {
+	return p.pool.Get().(*Ring)
+}
+
+//Put resets 'b' and returns it to the pool.
+//
+// Rings whose capacity has been changed since Get are not reused.
+func (p *Pool) Put(b *Ring) {
+	b.lock.Lock()
+	capacity := b.capacity
+	b.clear()
+	b.unlock()
+	if capacity != p.capacity {
+		return
+	}
+	p.pool.Put(b)
+}
+
+//clear removes all values, and releases them, and resets the ring's state as if it was new.
+//
+// The options are kept.
+func (b *ring) clear() {
+	for i := range b.buf {
+		b.buf[i] = nil
+	}
+	b.size = 0
+	b.head = -1
+	b.bytes = 0
+	b.seq = 0
+	b.stats.dropped.Store(0)
+	b.tags = nil
+	b.generations, b.positions = nil, nil
+	b.watches = nil
+	b.reserved, b.tickets, b.committed, b.pending = 0, 0, 0, nil
+	b.snapshot.Store(nil)
+	if b.occupancy != nil {
+		b.occupancy.ring.remove(b.occupancy.ring.size)
+		b.initOccupancy()
+	}
+}
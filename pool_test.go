@@ -0,0 +1,50 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	p := NewPool(5)
+	b := p.Get()
+	if b.Capacity() != 5 || b.Size() != 0 {
+		t.Fatalf("Invalid capacity/size %v/%v, expecting %v/%v", b.Capacity(), b.Size(), 5, 0)
+	}
+	b.Add(1, 2, 3)
+	p.Put(b)
+	if b.Size() != 0 {
+		t.Fatalf("Invalid size %v, expecting %v", b.Size(), 0)
+	}
+	for i, v := range b.buf {
+		if v != nil {
+			t.Fatalf("slot %v should have been released, got %v", i, v)
+		}
+	}
+	b = p.Get()
+	if err := b.Add(1, 2, 3, 4, 5); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestPoolFresh(t *testing.T) {
+	p := NewPool(5, WithPowerOfTwo())
+	b := p.Get()
+	b.Add(1, 2, 3)
+	b.PushTagged(4, "x")
+	b.Push(5, 6, 7, 8, 9, 10)
+	b.Watch(func(*Ring) float64 { return 0 }, 1, func(float64) {})
+	p.Put(b)
+	if p.capacity != 8 {
+		t.Fatalf("The pool should keep rings of capacity %v, got %v", 8, p.capacity)
+	}
+	c := b // the ring as it may be reused, sync.Pool does not guarantee it
+	if c.Size() != 0 || c.Dropped() != 0 || c.Sequence() != 0 || c.tags != nil || c.watches != nil {
+		t.Fatalf("A reused ring should be fresh, got size %v, dropped %v, sequence %v, tags %v, watches %v",
+			c.Size(), c.Dropped(), c.Sequence(), c.tags, c.watches)
+	}
+	c.Add(1)
+	if fmt.Sprint(c.Values()) != "[1]" {
+		t.Fatalf("Invalid reused ring %v", c.Values())
+	}
+}
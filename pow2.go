@@ -0,0 +1,48 @@
+package ringbuffer
+
+//WithPowerOfTwo rounds the ring's capacity up to a power of two.
+//
+// Index computations are then a simple bitmask, instead of a modulo, which speeds up Get and Push in tight loops.
+func WithPowerOfTwo() Option {
+	return func(b *ring) {
+		b.pow2 = true
+		b.capacity = powerOfTwo(b.capacity)
+	}
+}
+
+//powerOfTwo returns the smallest power of two greater than or equal to 'n' (and 0 for 0).
+func powerOfTwo(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+//next computes the absolute index of the head moved by 'i'.
+func (b *ring) next(i int) int {
+	if b.mask == 0 {
+		return Next(i, b.head, len(b.buf))
+	}
+	return (b.head + i) & b.mask
+}
+
+//index computes the absolute position of the ring's index 'i'.
+func (b *ring) index(i int) int {
+	if b.mask == 0 {
+		return Index(i, b.head, b.size, len(b.buf))
+	}
+	if b.size == 0 {
+		return -1
+	}
+	if uint(i) >= uint(b.size) { // fold i into [0, size[
+		i %= b.size
+		if i < 0 {
+			i += b.size
+		}
+	}
+	return (b.head - i) & b.mask
+}
@@ -0,0 +1,62 @@
+package ringbuffer
+
+import "testing"
+
+func TestPowerOfTwo(t *testing.T) {
+	b := New(5, WithPowerOfTwo())
+	if b.Capacity() != 8 {
+		t.Fatalf("Invalid capacity %v, expecting %v", b.Capacity(), 8)
+	}
+	x := New(8)
+	for i := 0; i < 30; i++ {
+		if i < 6 {
+			b.Add(i)
+			x.Add(i)
+		} else {
+			b.Push(i, -i)
+			x.Push(i, -i)
+		}
+		if !equals(b, x) {
+			t.Fatalf("masked ring differs:\nreal%v\ngold%v\n", b.buf, x.buf)
+		}
+		for j := -2 * b.Size(); j < 2*b.Size(); j++ {
+			if b.index(j) != Index(j, b.head, b.size, len(b.buf)) {
+				t.Fatalf("masked index %v differs: %v instead of %v", j, b.index(j), Index(j, b.head, b.size, len(b.buf)))
+			}
+		}
+	}
+	b.SetCapacity(9)
+	if b.Capacity() != 16 || len(b.buf) != 16 || !equals(b, x) {
+		t.Fatalf("Invalid capacity %v, expecting %v", b.Capacity(), 16)
+	}
+
+	b = New(20, WithPowerOfTwo(), WithLazyAllocation())
+	b.Add(1, 2, 3, 4, 5, 6, 7, 8, 9)
+	if len(b.buf) != 16 {
+		t.Fatalf("Invalid buffer size %v, expecting %v", len(b.buf), 16)
+	}
+}
+
+func BenchmarkPush(b *testing.B) {
+	r := New(1000)
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Push(i)
+		r.Get(i)
+	}
+}
+
+func BenchmarkPushPowerOfTwo(b *testing.B) {
+	r := New(1000, WithPowerOfTwo())
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Push(i)
+		r.Get(i)
+	}
+}
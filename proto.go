@@ -0,0 +1,125 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+//snapshot.proto field numbers.
+const (
+	protoCapacity     = 1
+	protoHeadSequence = 2
+	protoElements     = 3
+)
+
+//MarshalSnapshot encodes the ring in the protobuf format defined in snapshot.proto.
+//
+// 'encode' converts each value to bytes, in a format the receiving side can decode.
+func (b *Ring) MarshalSnapshot(encode func(v interface{}) ([]byte, error)) ([]byte, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.marshalSnapshot(encode)
+}
+
+//marshalSnapshot encodes the ring as a protobuf message (see MarshalSnapshot).
+func (b *ring) marshalSnapshot(encode func(v interface{}) ([]byte, error)) ([]byte, error) {
+	var data []byte
+	data = appendProtoVarint(data, protoCapacity, uint64(b.capacity))
+	data = appendProtoVarint(data, protoHeadSequence, b.seq)
+	for i := b.size - 1; i >= 0; i-- {
+		e, err := encode(b.buf[b.index(i)])
+		if err != nil {
+			return nil, err
+		}
+		data = binary.AppendUvarint(data, protoElements<<3|2)
+		data = binary.AppendUvarint(data, uint64(len(e)))
+		data = append(data, e...)
+	}
+	return data, nil
+}
+
+//maxSnapshotCapacity is the largest capacity UnmarshalSnapshot accepts, an int on every platform.
+const maxSnapshotCapacity = 1<<31 - 1
+
+//UnmarshalSnapshot creates a ring from a snapshot encoded by MarshalSnapshot (or any protobuf implementation).
+//
+// 'decode' converts the bytes back to values. Unknown fields are skipped.
+// It fails with ErrSnapshot if the data is malformed, or declares a capacity over 1<<31-1, or a capacity or a head sequence
+// below its number of elements. A missing head sequence defaults to the number of elements.
+// The buffer is allocated lazily (see WithLazyAllocation): a big declared capacity only costs what is used.
+func UnmarshalSnapshot(data []byte, decode func(p []byte) (interface{}, error), options ...Option) (*Ring, error) {
+	var capacity, seq uint64
+	var hasSeq bool
+	var elements [][]byte
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, ErrSnapshot
+		}
+		data = data[n:]
+		var x uint64
+		var field []byte
+		switch key & 7 { // wire type
+		case 0: // varint
+			if x, n = binary.Uvarint(data); n <= 0 {
+				return nil, ErrSnapshot
+			}
+			data = data[n:]
+		case 1, 5: // fixed64, fixed32
+			size := 8
+			if key&7 == 5 {
+				size = 4
+			}
+			if len(data) < size {
+				return nil, ErrSnapshot
+			}
+			data = data[size:]
+		case 2: // length-delimited
+			if x, n = binary.Uvarint(data); n <= 0 || x > uint64(len(data)-n) {
+				return nil, ErrSnapshot
+			}
+			field, data = data[n:n+int(x)], data[n+int(x):]
+		default:
+			return nil, fmt.Errorf("%w: unsupported wire type %d of field %d", ErrSnapshot, key&7, key>>3)
+		}
+		switch key {
+		case protoCapacity<<3 | 0:
+			capacity = x
+		case protoHeadSequence<<3 | 0:
+			seq, hasSeq = x, true
+		case protoElements<<3 | 2:
+			elements = append(elements, field)
+		}
+	}
+	if capacity > maxSnapshotCapacity {
+		return nil, fmt.Errorf("%w: capacity %d over %d", ErrSnapshot, capacity, maxSnapshotCapacity)
+	}
+	if uint64(len(elements)) > capacity {
+		return nil, fmt.Errorf("%w: %d elements over capacity %d", ErrSnapshot, len(elements), capacity)
+	}
+	if !hasSeq { // omitted by proto3 encoders when zero
+		seq = uint64(len(elements))
+	}
+	if seq < uint64(len(elements)) {
+		return nil, fmt.Errorf("%w: head sequence %d below the %d elements", ErrSnapshot, seq, len(elements))
+	}
+	b := New(int(capacity), append([]Option{WithLazyAllocation()}, options...)...)
+	for _, e := range elements {
+		v, err := decode(e)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.addOne(v); err != nil {
+			return nil, fmt.Errorf("%w: element %d rejected: %w", ErrSnapshot, b.size, err)
+		}
+	}
+	b.seq = seq
+	b.publish()
+	return b, nil
+}
+
+//appendProtoVarint appends a varint field.
+func appendProtoVarint(data []byte, field int, x uint64) []byte {
+	data = binary.AppendUvarint(data, uint64(field)<<3)
+	return binary.AppendUvarint(data, x)
+}
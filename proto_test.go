@@ -0,0 +1,65 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSnapshotProto(t *testing.T) {
+	b := New(3)
+	b.Add("a", "b")
+	b.Push("c", "d")
+	data, err := b.MarshalSnapshot(func(v interface{}) ([]byte, error) { return []byte(v.(string)), nil })
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// capacity=3 head_sequence=4 elements="c" elements="d"
+	want := []byte{0x08, 3, 0x10, 4, 0x1a, 1, 'c', 0x1a, 1, 'd'}
+	if !bytes.Equal(data, want) {
+		t.Fatalf("Invalid encoding % x, expecting % x", data, want)
+	}
+	c, err := UnmarshalSnapshot(data, func(p []byte) (interface{}, error) { return string(p), nil })
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if c.Capacity() != 3 || c.Sequence() != 4 || !equals(b, c) {
+		t.Fatalf("Invalid ring %v, expecting %v", c, b)
+	}
+	if _, err := UnmarshalSnapshot(data[:len(data)-1], nil); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("should have failed with ErrSnapshot, got %v", err)
+	}
+	if _, err := UnmarshalSnapshot([]byte{0x08, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40}, nil); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("A huge capacity should fail with ErrSnapshot, got %v", err)
+	}
+	if c, err := UnmarshalSnapshot([]byte{0x08, 0xff, 0xff, 0xff, 0xff, 0x07}, nil); err != nil || c.Capacity() != 1<<31-1 || len(c.buf) != 0 {
+		t.Fatalf("A big capacity should be allocated lazily, got %v", err)
+	}
+	// unknown fields of every wire type: 4 varint, 5 fixed64, 6 bytes, 7 fixed32
+	unknown := append([]byte{0x20, 0x96, 0x01, 0x29, 1, 2, 3, 4, 5, 6, 7, 8, 0x32, 2, 'x', 'y', 0x3d, 1, 2, 3, 4}, data...)
+	if c, err := UnmarshalSnapshot(unknown, func(p []byte) (interface{}, error) { return string(p), nil }); err != nil || !equals(b, c) {
+		t.Fatalf("Unknown fields should be skipped, got %v, %v", c, err)
+	}
+	if _, err := UnmarshalSnapshot([]byte{0x29, 1, 2}, nil); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("A truncated fixed64 should fail with ErrSnapshot, got %v", err)
+	}
+	// capacity 3, no head sequence (proto3 omits zeros), elements "a" and "b"
+	noSeq := []byte{0x08, 3, 0x1a, 1, 'a', 0x1a, 1, 'b'}
+	c, err = UnmarshalSnapshot(noSeq, func(p []byte) (interface{}, error) { return string(p), nil })
+	if err != nil || c.Sequence() != 2 || fmt.Sprint(c.Values()) != "[a b]" {
+		t.Fatalf("A missing head sequence should default to the number of elements, got %v, %v", c, err)
+	}
+	cur, _ := c.Cursor(0)
+	if v, ok := cur.Get(); !ok || v != "b" {
+		t.Fatalf("The loaded ring should be consistent, got %v, %v", v, ok)
+	}
+	if _, err := UnmarshalSnapshot(append([]byte{0x10, 1}, noSeq...), nil); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("A head sequence below the number of elements should fail with ErrSnapshot, got %v", err)
+	}
+	_, err = UnmarshalSnapshot(data, func(p []byte) (interface{}, error) { return string(p), nil },
+		WithBudget(1, func(v interface{}) int { return 1 }))
+	if !errors.Is(err, ErrSnapshot) || !errors.Is(err, ErrFull) {
+		t.Fatalf("An element rejected by the options should fail, got %v", err)
+	}
+}
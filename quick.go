@@ -0,0 +1,38 @@
+package ringbuffer
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+//Generate returns a random *Ring, implementing testing/quick.Generator so that functions accepting rings
+//can be property-tested with quick.Check.
+//
+// The ring has a capacity up to 'size', random int values, and its head at a random position in the buffer.
+func (b *Ring) Generate(rand *rand.Rand, size int) reflect.Value {
+	r := New(0)
+	r.generate(rand, size)
+	r.publish()
+	return reflect.ValueOf(r)
+}
+
+//Generate returns a random *Unlocked, implementing testing/quick.Generator (see Ring.Generate).
+func (b *Unlocked) Generate(rand *rand.Rand, size int) reflect.Value {
+	r := NewUnlocked(0)
+	r.generate(rand, size)
+	return reflect.ValueOf(r)
+}
+
+//generate sets up a random ring (see Ring.Generate).
+func (b *ring) generate(rand *rand.Rand, size int) {
+	b.setCapacity(rand.Intn(size + 1))
+	offset := rand.Intn(b.capacity + 1)
+	for i := 0; i < offset; i++ { // move the head
+		b.addOne(0)
+	}
+	b.remove(offset)
+	n := rand.Intn(b.capacity + 1)
+	for i := 0; i < n; i++ {
+		b.addOne(rand.Int())
+	}
+}
@@ -0,0 +1,25 @@
+package ringbuffer
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestGenerate(t *testing.T) {
+	f := func(b *Ring, u *Unlocked) bool {
+		values := b.Values()
+		b.Push(-1)
+		return b.Size() <= b.Capacity() && len(values) == b.Size() && u.Size() <= u.Capacity()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Fatal(err)
+	}
+	heads := map[int]bool{}
+	quick.Check(func(b *Ring) bool {
+		heads[b.head] = true
+		return true
+	}, &quick.Config{MaxCount: 200})
+	if len(heads) < 10 {
+		t.Fatalf("The heads should be spread over the buffer, got %v", heads)
+	}
+}
@@ -0,0 +1,52 @@
+package ringbuffer
+
+import "fmt"
+
+//Producer writes into a ring, occupying at most a quota of it (see Ring.Producer).
+type Producer struct {
+	ring  *Ring
+	quota int
+	seqs  *Unlocked // sequence numbers of the producer's values, still in the ring or not
+}
+
+//Producer registers a producer that may not occupy more than 'quota' values of the ring.
+//
+// In a shared ring, a chatty producer then only discards its own new values,
+// instead of pushing everyone else's out of the ring.
+// The ring's lock protects the producer's state: it is safe for concurrent use.
+func (b *Ring) Producer(quota int) *Producer {
+	return &Producer{ring: b, quota: quota, seqs: NewUnlocked(quota)}
+}
+
+//Push pushes 'value' into the ring (see Ring.Push), adding it if the ring is not full.
+//
+// It fails with an error matching ErrFull, and discards 'value', if the producer's quota is reached.
+func (p *Producer) Push(value interface{}) error {
+	b := p.ring
+	b.lock.Lock()
+	defer b.unlock()
+	p.prune()
+	if p.seqs.size >= p.quota {
+		b.stats.dropped.Add(1)
+		return fmt.Errorf("%w: producer quota of %d values reached", ErrFull, p.quota)
+	}
+	b.put(value)
+	p.seqs.add(b.seq)
+	return nil
+}
+
+//Len returns the number of the producer's values in the ring.
+func (p *Producer) Len() int {
+	p.ring.lock.Lock()
+	defer p.ring.lock.Unlock()
+	p.prune()
+	return p.seqs.size
+}
+
+//prune forgets the values that have left the ring.
+func (p *Producer) prune() {
+	oldest := p.ring.seq - uint64(p.ring.size) + 1
+	for p.seqs.size > 0 && p.seqs.buf[p.seqs.index(-1)].(uint64) < oldest {
+		p.seqs.evict(1)
+	}
+}
@@ -0,0 +1,33 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestProducer(t *testing.T) {
+	b := New(4)
+	chatty, quiet := b.Producer(2), b.Producer(2)
+	quiet.Push("q1")
+	chatty.Push("c1")
+	chatty.Push("c2")
+	if err := chatty.Push("c3"); !errors.Is(err, ErrFull) {
+		t.Fatalf("Push should fail over the quota, got %v", err)
+	}
+	quiet.Push("q2")
+	if fmt.Sprint(b.Values()) != "[q1 c1 c2 q2]" || b.Dropped() != 1 {
+		t.Fatalf("Invalid ring %v", b)
+	}
+	b.Push("x") // evicts q1
+	if quiet.Len() != 1 || chatty.Len() != 2 {
+		t.Fatalf("Invalid producer lengths %v, %v", quiet.Len(), chatty.Len())
+	}
+	b.Remove(1) // removes c1
+	if err := chatty.Push("c3"); err != nil {
+		t.Fatalf("Push should succeed under the quota, got %v", err)
+	}
+	if fmt.Sprint(b.Values()) != "[c2 q2 x c3]" {
+		t.Fatalf("Invalid ring %v", b)
+	}
+}
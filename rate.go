@@ -0,0 +1,56 @@
+package ringbuffer
+
+import "time"
+
+//RateCounter counts events per second over a trailing window.
+//
+// Events are counted in the buckets of a BucketRing, the window can then be anything up to its history.
+type RateCounter struct {
+	buckets *BucketRing
+}
+
+//NewRateCounter creates a counter keeping the events of the last 'n' buckets of 'width', plus the current one.
+//
+// The options configure the underlying ring, e.g. WithClock.
+func NewRateCounter(n int, width time.Duration, options ...Option) *RateCounter {
+	return &RateCounter{buckets: NewBucketRing(n, width, options...)}
+}
+
+//Mark records 'n' events, now.
+func (r *RateCounter) Mark(n int) {
+	r.buckets.Record(float64(n))
+}
+
+//Rate returns the number of events per second over the trailing 'window'.
+//
+// A bucket partially in the window counts for the part inside, assuming its events were evenly spread.
+// The window is cut down to the counter's history, so that a young counter is not underestimated.
+func (r *RateCounter) Rate(window time.Duration) float64 {
+	var events float64
+	var covered time.Duration
+	r.buckets.do(func(now time.Time) {
+		from := now.Add(-window)
+		add := func(b Bucket, end time.Time) {
+			if !end.After(from) {
+				return
+			}
+			start := b.Start
+			if start.Before(from) {
+				events += b.Sum * float64(end.Sub(from)) / float64(end.Sub(start))
+				start = from
+			} else {
+				events += b.Sum
+			}
+			covered += end.Sub(start)
+		}
+		add(r.buckets.current, now)
+		for i := 0; i < r.buckets.ring.size; i++ {
+			b := r.buckets.ring.buf[r.buckets.ring.index(i)].(Bucket)
+			add(b, b.End())
+		}
+	})
+	if covered <= 0 {
+		return 0
+	}
+	return events / covered.Seconds()
+}
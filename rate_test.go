@@ -0,0 +1,29 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateCounter(t *testing.T) {
+	c := NewManualClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := NewRateCounter(10, time.Second, WithClock(c))
+	if r.Rate(time.Minute) != 0 {
+		t.Fatalf("A new counter has no rate, got %v", r.Rate(time.Minute))
+	}
+	r.Mark(10)
+	c.Advance(time.Second)
+	r.Mark(20)
+	c.Advance(500 * time.Millisecond)
+	r.Mark(5)
+	if rate := r.Rate(time.Minute); rate != 35/1.5 {
+		t.Fatalf("The window should be cut down to the history, got %v", rate)
+	}
+	if rate := r.Rate(time.Second); rate != 25+10/2. {
+		t.Fatalf("Partial buckets should be prorated, got %v", rate)
+	}
+	c.Advance(time.Hour)
+	if rate := r.Rate(time.Minute); rate != 0 {
+		t.Fatalf("Old events should be forgotten, got %v", rate)
+	}
+}
@@ -0,0 +1,244 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+//Operation is a mutation of a ring, recorded by a Recorder.
+type Operation struct {
+	Op   string        // "Add", "Push" or "Remove"
+	Args []interface{} // the values, or the count
+	Err  error         // the error returned by Add
+
+	// the resulting state
+	Head, Size int
+}
+
+func (o Operation) String() string {
+	args := make([]string, len(o.Args))
+	for i, a := range o.Args {
+		args[i] = fmt.Sprintf("%#v", a)
+	}
+	return fmt.Sprintf("%s(%s)", o.Op, strings.Join(args, ", "))
+}
+
+//apply applies the operation to 'b'.
+func (o Operation) apply(b Buffer) error {
+	switch o.Op {
+	case "Add":
+		return b.Add(o.Args...)
+	case "Push":
+		b.Push(o.Args...)
+	case "Remove":
+		b.Remove(o.Args[0].(int))
+	}
+	return nil
+}
+
+//Recorder decorates a Buffer, recording its last mutations in a bounded journal,
+//to attach exact reproduction steps to bug reports (see WriteTest).
+//
+// The mutations must go through the recorder. It is safe for concurrent use if the recorded buffer is.
+type Recorder struct {
+	Buffer
+	lock    sync.Mutex
+	journal *Unlocked // of Operations
+	base    *Unlocked // a copy of the buffer, before the journal's oldest operation
+}
+
+//stated is implemented by the buffers exposing their layout: Ring and Unlocked.
+type stated interface {
+	state() Layout
+}
+
+//state returns the ring's layout.
+func (b *Ring) state() Layout {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.ring.state()
+}
+
+//state returns the ring's layout.
+func (b *ring) state() Layout {
+	return Layout{Head: b.head, Size: b.size, Capacity: len(b.buf)}
+}
+
+//optioned is implemented by the buffers exposing their options: Ring and Unlocked.
+type optioned interface {
+	recordedOptions() (options []Option, source []string, err error)
+}
+
+//recordedOptions returns the ring's options (see ring.recordedOptions).
+func (b *Ring) recordedOptions() ([]Option, []string, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.ring.recordedOptions()
+}
+
+//recordedOptions returns the ring's options that shape its contents, and their Go source.
+//
+// It fails with errors.ErrUnsupported for the options that cannot be written as Go source.
+// The other options (like WithClock) do not change the replayed contents, and are left out.
+func (b *ring) recordedOptions() (options []Option, source []string, err error) {
+	if b.sizeOf != nil {
+		err = fmt.Errorf("recording a ring with WithBudget: %w", errors.ErrUnsupported)
+	}
+	if b.pow2 {
+		options, source = append(options, WithPowerOfTwo()), append(source, "ringbuffer.WithPowerOfTwo()")
+	}
+	if b.lazy {
+		options, source = append(options, WithLazyAllocation()), append(source, "ringbuffer.WithLazyAllocation()")
+	}
+	if b.oldestFirst {
+		options, source = append(options, WithOldestFirst()), append(source, "ringbuffer.WithOldestFirst()")
+	}
+	return options, source, err
+}
+
+//NewRecorder creates a recorder of the last 'journal' mutations of 'b'.
+//
+// The base is created with the options of 'b' that shape its contents (see WriteTest).
+func NewRecorder(b Buffer, journal int) *Recorder {
+	var options []Option
+	if o, ok := b.(optioned); ok {
+		options, _, _ = o.recordedOptions()
+	}
+	r := &Recorder{Buffer: b, journal: NewUnlocked(journal), base: NewUnlocked(b.Capacity(), options...)}
+	for _, o := range r.setup() {
+		o.apply(r.base)
+	}
+	return r
+}
+
+//setup returns the operations that lay out a new ring exactly like the recorded buffer.
+func (r *Recorder) setup() []Operation {
+	var values []interface{}
+	if v, ok := r.Buffer.(interface{ Values() []interface{} }); ok {
+		values = v.Values()
+	} else {
+		values = make([]interface{}, r.Buffer.Size())
+		for i := range values {
+			values[len(values)-1-i], _ = r.Buffer.Get(i)
+		}
+	}
+	tail := 0
+	if s, ok := r.Buffer.(stated); ok {
+		if l := s.state(); l.Size > 0 && l.Capacity == r.Buffer.Capacity() {
+			tail = l.Tail()
+		}
+	}
+	return layOut(r.Buffer.Capacity(), tail, values)
+}
+
+//layOut returns the operations that lay out 'values' in a new ring of 'capacity', the oldest at the position 'tail':
+// add fillers up to the tail, and the values, then remove the fillers.
+func layOut(capacity, tail int, values []interface{}) []Operation {
+	if len(values) == 0 {
+		return nil
+	}
+	n := min(len(values), capacity-tail)
+	first := make([]interface{}, tail, tail+n)
+	for i := range first {
+		first[i] = 0
+	}
+	ops := []Operation{{Op: "Add", Args: append(first, values[:n]...)}}
+	if tail > 0 {
+		ops = append(ops, Operation{Op: "Remove", Args: []interface{}{tail}})
+	}
+	if n < len(values) {
+		ops = append(ops, Operation{Op: "Add", Args: values[n:]})
+	}
+	return ops
+}
+
+//Add adds 'values' to the buffer, and records it.
+func (r *Recorder) Add(values ...interface{}) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := r.Buffer.Add(values...)
+	r.record(Operation{Op: "Add", Args: values, Err: err})
+	return err
+}
+
+//Push pushes 'values' into the buffer, and records it.
+func (r *Recorder) Push(values ...interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Buffer.Push(values...)
+	r.record(Operation{Op: "Push", Args: values})
+}
+
+//Remove removes 'count' values from the buffer, and records it.
+func (r *Recorder) Remove(count int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Buffer.Remove(count)
+	r.record(Operation{Op: "Remove", Args: []interface{}{count}})
+}
+
+//record journals 'o', with the resulting state, replaying the operation it discards on the base.
+func (r *Recorder) record(o Operation) {
+	o.Args = append([]interface{}(nil), o.Args...)
+	o.Head, o.Size = -1, r.Buffer.Size()
+	if s, ok := r.Buffer.(stated); ok {
+		o.Head = s.state().Head
+	}
+	if r.journal.size > 0 && r.journal.size == r.journal.capacity {
+		oldest, _ := r.journal.pop()
+		oldest.(Operation).apply(r.base)
+	}
+	if r.journal.addOne(o) != nil { // no journal at all
+		o.apply(r.base)
+	}
+}
+
+//Journal returns the recorded operations, from the oldest to the newest.
+func (r *Recorder) Journal() []Operation {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	journal := make([]Operation, 0, r.journal.size)
+	for _, o := range r.journal.values() {
+		journal = append(journal, o.(Operation))
+	}
+	return journal
+}
+
+//WriteTest writes a Go test function named 'name' replaying the journal on a new Ring:
+// the ring is first laid out as the buffer was before the journal's oldest operation, and each operation
+// is commented with its recorded result. The values must print as Go literals (see the %#v format).
+//
+// The ring is created with the buffer's options that shape its contents: WithPowerOfTwo, WithLazyAllocation
+// and WithOldestFirst. It fails with errors.ErrUnsupported if the buffer uses WithBudget, that cannot be written.
+func (r *Recorder) WriteTest(w io.Writer, name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var options []string
+	if o, ok := r.Buffer.(optioned); ok {
+		_, source, err := o.recordedOptions()
+		if err != nil {
+			return err
+		}
+		options = source
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "func %s(t *testing.T) {\n", name)
+	fmt.Fprintf(bw, "\tb := ringbuffer.New(%s)\n", strings.Join(append([]string{fmt.Sprint(r.base.capacity)}, options...), ", "))
+	for _, o := range layOut(r.base.capacity, max(r.base.index(-1), 0), r.base.values()) {
+		fmt.Fprintf(bw, "\tb.%v\n", o)
+	}
+	for _, v := range r.journal.values() {
+		o := v.(Operation)
+		fmt.Fprintf(bw, "\tb.%v // head %d, size %d", o, o.Head, o.Size)
+		if o.Err != nil {
+			fmt.Fprintf(bw, ", error %q", o.Err)
+		}
+		bw.WriteByte('\n')
+	}
+	fmt.Fprintf(bw, "\tt.Logf(\"%%#v\", b)\n}\n")
+	return bw.Flush()
+}
@@ -0,0 +1,79 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	b := New(3)
+	b.Add(1, 2, 3)
+	b.Push(4)
+	r := NewRecorder(b, 2)
+	if fmt.Sprint(r.base.Values()) != "[2 3 4]" || r.base.head != b.head {
+		t.Fatalf("The base should be laid out like the buffer, got %#v", r.base)
+	}
+	r.Remove(1)
+	r.Add(5)
+	r.Add(6, 7)
+	if j := r.Journal(); len(j) != 2 || j[0].String() != "Add(5)" || j[1].Err == nil || j[0].Head != 1 || j[0].Size != 3 {
+		t.Fatalf("Invalid journal %+v", j)
+	}
+	if fmt.Sprint(r.base.Values()) != "[3 4]" || r.base.head != 0 {
+		t.Fatalf("The base should replay the discarded operations, got %#v", r.base)
+	}
+	var w strings.Builder
+	r.WriteTest(&w, "TestBug")
+	want := `func TestBug(t *testing.T) {
+	b := ringbuffer.New(3)
+	b.Add(0, 0, 3)
+	b.Remove(2)
+	b.Add(4)
+	b.Add(5) // head 1, size 3
+	b.Add(6, 7) // head 1, size 3, error "full ring buffer: cannot add 2 values to 3, over capacity 3 by 2"
+	t.Logf("%#v", b)
+}
+`
+	if w.String() != want {
+		t.Fatalf("Invalid test:\n%s", w.String())
+	}
+
+	// replaying the setup lays out the ring identically
+	c := New(3)
+	c.Add(0, 0, 3)
+	c.Remove(2)
+	c.Add(4)
+	if c.head != r.base.head || fmt.Sprint(c.Values()) != "[3 4]" {
+		t.Fatalf("Invalid replay %#v", c)
+	}
+
+	u := NewRecorder(NewUnlocked(2), 0)
+	u.Push(1)
+	u.Add(1)
+	if len(u.Journal()) != 0 || fmt.Sprint(u.base.Values()) != "[1]" {
+		t.Fatalf("Without journal, the base should follow the buffer, got %#v", u.base)
+	}
+}
+
+func TestRecorderOptions(t *testing.T) {
+	b := New(3, WithPowerOfTwo(), WithOldestFirst(), WithClock(SystemClock))
+	r := NewRecorder(b, 1)
+	r.Add(1)
+	var w strings.Builder
+	if err := r.WriteTest(&w, "TestBug"); err != nil {
+		t.Fatalf("WriteTest failed: %v", err)
+	}
+	if !strings.Contains(w.String(), "b := ringbuffer.New(4, ringbuffer.WithPowerOfTwo(), ringbuffer.WithOldestFirst())\n") {
+		t.Fatalf("The test should create the ring with its options, got:\n%s", w.String())
+	}
+	if !r.base.pow2 || !r.base.oldestFirst {
+		t.Fatalf("The base should be created with the buffer's options, got %#v", r.base)
+	}
+
+	r = NewRecorder(New(3, WithBudget(10, func(v interface{}) int { return 1 })), 1)
+	if err := r.WriteTest(new(strings.Builder), "TestBug"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("WriteTest should refuse a budgeted ring, got %v", err)
+	}
+}
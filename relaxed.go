@@ -0,0 +1,29 @@
+package ringbuffer
+
+import "time"
+
+//WithRelaxedReads lets Get and Values skip locking, observing a state at most 'staleness' old.
+//
+// Readers share a copy of the ring, refreshed (under the read lock) by the first reader finding it too old.
+// Writers pay nothing, unlike WithSnapshots, but readers may miss the latest modifications:
+// it is meant for monitoring readers, that prefer fast reads over strict consistency.
+// Size, Capacity and Dropped never lock the ring anyway.
+//
+// It has no effect on Unlocked rings, and in snapshot mode.
+func WithRelaxedReads(staleness time.Duration) Option {
+	return func(b *ring) {
+		b.relaxed = staleness
+	}
+}
+
+//relaxedSnapshot returns a copy of the ring at most 'relaxed' old.
+func (b *Ring) relaxedSnapshot() *snapshot {
+	if s := b.snapshot.Load(); s != nil && b.now().Sub(s.taken) < b.relaxed {
+		return s
+	}
+	b.lock.RLock()
+	s := &snapshot{values: b.values(), taken: b.now()}
+	b.lock.RUnlock()
+	b.snapshot.Store(s)
+	return s
+}
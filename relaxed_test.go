@@ -0,0 +1,30 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelaxedReads(t *testing.T) {
+	b := New(3, WithRelaxedReads(time.Hour))
+	b.Add(1, 2)
+	if v, _ := b.Get(0); v != 2 {
+		t.Fatalf("Get(0) should return %v, got %v", 2, v)
+	}
+	b.Add(3)
+	if v, _ := b.Get(0); v != 2 {
+		t.Fatalf("Relaxed Get(0) should return the stale %v, got %v", 2, v)
+	}
+	if b.Size() != 3 {
+		t.Fatalf("Size should not be stale, got %v", b.Size())
+	}
+
+	b = New(3, WithRelaxedReads(time.Nanosecond))
+	b.Add(1, 2)
+	b.Get(0)
+	b.Add(3)
+	time.Sleep(time.Millisecond)
+	if v, _ := b.Get(0); v != 3 {
+		t.Fatalf("Get(0) should return the refreshed %v, got %v", 3, v)
+	}
+}
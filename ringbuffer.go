@@ -22,50 +22,169 @@ package ringbuffer
 
 import (
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// Errors returned by this package may wrap the following ones with some context: use errors.Is to test them.
 var (
 	//ErrEmpty is the error returned when the ring is empty, preventing the function completion.
 	ErrEmpty = errors.New("empty ring buffer")
 	//ErrFull is the error returned when the ring is full, preventing the function completion.
 	ErrFull = errors.New("full ring buffer")
+	//ErrRange is the error returned when a range of values exceeds the ring's size.
+	ErrRange = errors.New("range out of ring buffer")
+	//ErrNotNumeric is the error returned when a numeric operation meets a value that is not a number (see Sample).
+	ErrNotNumeric = errors.New("not a numeric value")
+	//ErrSnapshot is the error returned when decoding an invalid snapshot.
+	ErrSnapshot = errors.New("invalid ring buffer snapshot")
+	//ErrClosed is the error returned when using a closed (or shut down) structure.
+	ErrClosed = errors.New("closed ring buffer")
+	//ErrEncrypted is the error returned when reading an encrypted ring file without its key (see SaveFileEncrypted).
+	ErrEncrypted = errors.New("encrypted ring buffer file")
+	//ErrSlot is the panic value when publishing or canceling a slot twice (see ReserveSlot).
+	ErrSlot = errors.New("ring buffer slot already published")
+
+	//EmptyError is the former name of ErrEmpty.
+	//
+	// Deprecated: use ErrEmpty.
+	EmptyError = ErrEmpty
+	//FullError is the former name of ErrFull.
+	//
+	// Deprecated: use ErrFull.
+	FullError = ErrFull
 )
 
 //Ring is a basic implementation of a circular buffer http://en.wikipedia.org/wiki/Circular_buffer
 // or Ring Buffer
+//
+// It is safe for concurrent use, see Unlocked for a single goroutine variant.
 type Ring struct {
-	lock       sync.RWMutex
+	lock sync.RWMutex
+	ring
+}
+
+//ring is the unsynchronized implementation shared by Ring and Unlocked.
+type ring struct {
 	head, size int
+	seq        uint64 // number of values added or pushed so far, that is the newest value's sequence number
 	buf        []interface{}
+	capacity   int  // the max size, len(buf) may be smaller with lazy allocation
+	lazy       bool // see WithLazyAllocation
+	pow2       bool // see WithPowerOfTwo
+	mask       int  // len(buf)-1 when len(buf) is a power of two (see WithPowerOfTwo)
+
+	// memory budget mode (see WithBudget)
+	budget, bytes int
+	sizeOf        func(v interface{}) int
+
+	stats  *counters
+	padded bool // see WithPadding
+
+	formatter func(v interface{}) string // see SetFormatter
+
+	// read-mostly mode (see WithSnapshots)
+	snapshots bool
+	snapshot  atomic.Pointer[snapshot]
+	relaxed   time.Duration // see WithRelaxedReads
+
+	clock     Clock       // see WithClock
+	occupancy *BucketRing // see WithOccupancy
+
+	waiter WaitStrategy // see WithWaitStrategy
+
+	oldestFirst bool // see WithOldestFirst
+
+	watches []chan struct{} // see Watch
+
+	fold func(evicted interface{}) // see WithConsolidation
+
+	// tags of the values, by sequence number (see PushTagged)
+	tags map[uint64][]string
+
+	// generations of the values moved by reorders, by sequence number, and the reverse (see Swap)
+	generations, positions map[uint64]uint64
+
+	// two-phase writes (see ReserveSlot)
+	reserved           int // room reserved for slots not published yet
+	tickets, committed uint64
+	pending            map[uint64]interface{} // published slots, waiting for the previous ones
 }
 
+//Option configures a ring at creation time.
+type Option func(b *ring)
+
 //New creates a new, empty ring buffer.
-func New(capacity int) (b *Ring) {
-	return &Ring{
-		buf:  make([]interface{}, capacity),
-		head: -1,
+func New(capacity int, options ...Option) (b *Ring) {
+	b = &Ring{}
+	b.init(capacity, options)
+	b.publish()
+	return b
+}
+
+//init sets up an empty ring.
+func (b *ring) init(capacity int, options []Option) {
+	b.head = -1
+	b.capacity = capacity
+	for _, option := range options {
+		option(b)
+	}
+	b.stats = newCounters(b.padded)
+	if b.waiter == nil {
+		b.waiter = ParkWait()
+	}
+	b.initOccupancy()
+	if !b.lazy {
+		b.resize(b.capacity)
 	}
 }
 
 // Add values to the Ring's head, increasing its size.
 //
-// If you try to add more values than it can, an ErrFull error is returned and no value is actually added.
+// If you try to add more values than it can, an *OverflowError (matching ErrFull) is returned and no value is actually added.
+// The check is performed under the lock, so concurrent Adds never overfill the ring.
 func (b *Ring) Add(values ...interface{}) error {
+	b.lock.Lock()
+	defer b.unlock()
+	return b.add(values...)
+}
+
+//TryAdd adds 'value' to the Ring's head, without blocking.
+//
+// It returns false, and does not add anything, if the ring is full or currently locked by another goroutine.
+func (b *Ring) TryAdd(value interface{}) bool {
+	if !b.lock.TryLock() {
+		return false
+	}
+	defer b.unlock()
+	return b.addOne(value) == nil
+}
+
+//add 'values' to the ring's head (see Add).
+func (b *ring) add(values ...interface{}) error {
 	if len(values) == 0 {
 		return nil
 	}
 	if len(values) == 1 {
-		return b.add(values[0])
-
+		if b.addOne(values[0]) != nil {
+			return b.overflow(values)
+		}
+		return nil
 	}
-	b.lock.Lock()
-	defer b.lock.Unlock()
 
 	//check that we will be able to fill it.
-	if b.size+len(values) > len(b.buf) {
-		return ErrFull
+	if b.size+b.reserved+len(values) > b.capacity {
+		return b.overflow(values)
+	}
+	bytes := b.bytesOf(values)
+	if b.sizeOf != nil && b.bytes+bytes > b.budget {
+		return b.overflow(values)
 	}
+	b.bytes += bytes
+	b.seq += uint64(len(values))
+	b.grow(b.size + len(values))
 
 	//alg: add as much as possible in a single copy, and repeat until exhaustion
 
@@ -76,7 +195,7 @@ func (b *Ring) Add(values ...interface{}) error {
 		// from next position, to the end of the buffer
 		// or from the beginning to the tail of the ring
 
-		next := Next(1, b.head, len(b.buf))
+		next := b.next(1)
 
 		//tail := Index(-1, b.head, b.size, len(b.buf)
 
@@ -100,7 +219,7 @@ func (b *Ring) Add(values ...interface{}) error {
 		}
 
 		// we adjust local variables (latest has moved, and so has size)
-		b.head = Next(n, b.head, len(b.buf))
+		b.head = b.next(n)
 		b.size += n // increase the inner size
 
 		// we remove from the source, the value copied.
@@ -115,32 +234,60 @@ func (b *Ring) Add(values ...interface{}) error {
 // If count is greater than the actual ring's size, the ring size is reset to zero.
 func (b *Ring) Remove(count int) {
 	b.lock.Lock()
-	defer b.lock.Unlock()
+	defer b.unlock()
+	b.remove(count)
+}
+
+//remove 'count' values from the ring's tail (see Remove).
+func (b *ring) remove(count int) {
 	if count <= 0 {
 		return
 	}
-
-	b.size -= count
-	if b.size <= 0 {
-		b.size = 0
-		b.head = -1 //small trick to mark as empty
-	}
+	b.evict(count)
 	return
 }
 
 //Push is equivalent to Remove then Add 'values' from the ring.
 //
 // It uses bulk operations (at most two).
+//
+// In budget mode (see WithBudget) Push removes as many values as needed to fit each new one instead.
 func (b *Ring) Push(values ...interface{}) {
+	b.lock.Lock()
+	defer b.unlock()
+	b.push(values...)
+}
+
+//PushReturning pushes 'value' into the ring (see Push), and returns the value it discarded.
+//
+// It returns false if nothing was discarded, because the ring was empty.
+// In budget mode, where several values may be discarded, only the oldest is returned.
+func (b *Ring) PushReturning(value interface{}) (evicted interface{}, ok bool) {
+	b.lock.Lock()
+	defer b.unlock()
+	return b.pushEvict(value)
+}
+
+//push 'values' into the ring, discarding the oldest ones (see Push).
+func (b *ring) push(values ...interface{}) {
+	b.seq += uint64(len(values))
+	if b.sizeOf != nil {
+		for _, v := range values {
+			b.pushBudget(v)
+		}
+		return
+	}
+	b.stats.dropped.Add(uint64(len(values)))
+	if b.fold != nil {
+		b.consolidate(values)
+	}
 	if len(values) == 0 || b.size == 0 {
 		return
 	}
 	if len(values) == 1 {
-		b.push(values[0])
+		b.pushOne(values[0])
 		return
 	}
-	b.lock.Lock()
-	defer b.lock.Unlock()
 	//alg: just write as much as you need after next
 
 	// if len(values) is greater than b.size it is useless to fully write it down.
@@ -153,22 +300,17 @@ func (b *Ring) Push(values ...interface{}) {
 	}
 	// now we need to write down values (that is never greater than b.size)
 
-	// next is the absolute index of the buffer head+1
-	next := Next(1, b.head, len(b.buf))
+	// the oldest values are discarded, release them first as they are not always overwritten
+	b.release(len(values))
 
-	// we are going to write down from 'next' toward the end of the buffer.
-	tgt := b.buf[next:]
-
-	//we copy as much as possible.
-	n := copy(tgt, values) //n is the number of copied values
-
-	// if we have exhausted the buffer (we have reached the end of the buffer) we need to start again from zero this time.
-	if n < len(values) { // there are still values to copy
-		copy(b.buf, values[n:]) //copy remaining from the begining this time.
-	}
-	//move the head
-	b.head = Next(len(values), b.head, len(b.buf))
+	// we are going to write down from head+1 toward the end of the buffer,
+	// and if we reach the end of the buffer, start again from zero.
+	first, second := b.span(b.next(1), len(values))
+	n := copy(first, values) //n is the number of copied values
+	copy(second, values[n:])
 
+	//move the head, once.
+	b.head = b.next(len(values))
 }
 
 //Get returns the value in the ring.
@@ -178,33 +320,224 @@ func (b *Ring) Push(values ...interface{}) {
 //   Get(-1) //is the oldest too
 //
 func (b *Ring) Get(i int) (interface{}, error) {
+	if b.snapshots {
+		return b.snapshot.Load().get(b.logical(i))
+	}
+	if b.relaxed > 0 {
+		return b.relaxedSnapshot().get(b.logical(i))
+	}
 	b.lock.RLock()
 	defer b.lock.RUnlock()
+	return b.get(i)
+}
+
+//get returns the value at index 'i' (see Get).
+func (b *ring) get(i int) (interface{}, error) {
 	if b.size == 0 {
 		return 0, ErrEmpty
 	}
-	position := Index(i, b.head, b.size, len(b.buf))
+	position := b.index(b.logical(i))
 	return b.buf[position], nil
 }
 
+//GetOrDefault returns the value in the ring (see Get), or 'def' if the ring is empty.
+func (b *Ring) GetOrDefault(i int, def interface{}) interface{} {
+	v, err := b.Get(i)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//Newest returns the newest value in the ring, whatever the index order (see WithOldestFirst).
+//
+// It fails with ErrEmpty if the ring is empty.
+func (b *Ring) Newest() (interface{}, error) {
+	return b.Get(b.logical(0))
+}
+
+//Oldest returns the oldest value in the ring, whatever the index order (see WithOldestFirst).
+//
+// It fails with ErrEmpty if the ring is empty.
+func (b *Ring) Oldest() (interface{}, error) {
+	return b.Get(b.logical(-1))
+}
+
+//MultiGet returns the values at several indexes (see Get), all read at once.
+func (b *Ring) MultiGet(indexes ...int) ([]interface{}, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.multiGet(indexes)
+}
+
+//multiGet returns the values at 'indexes' (see MultiGet).
+func (b *ring) multiGet(indexes []int) ([]interface{}, error) {
+	if b.size == 0 {
+		return nil, ErrEmpty
+	}
+	values := make([]interface{}, len(indexes))
+	for k, i := range indexes {
+		values[k] = b.buf[b.index(b.logical(i))]
+	}
+	return values, nil
+}
+
+//GetRange returns the 'n' values at the consecutive indexes from 'i', that is [Get(i), Get(i+1), ...].
+//
+// 'i' is interpreted as in Get, but the range cannot go past the oldest value: it fails with ErrRange if it does.
+func (b *Ring) GetRange(i, n int) ([]interface{}, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.getRange(i, n)
+}
+
+//getRange returns the 'n' values from the index 'i' (see GetRange).
+func (b *ring) getRange(i, n int) ([]interface{}, error) {
+	if b.size == 0 {
+		return nil, ErrEmpty
+	}
+	i %= b.size
+	if i < 0 {
+		i += b.size
+	}
+	if n < 0 || i+n > b.size {
+		return nil, fmt.Errorf("%w: %d values from index %d, size is %d", ErrRange, n, i, b.size)
+	}
+	values := make([]interface{}, n)
+	if n == 0 {
+		return values, nil
+	}
+	if b.oldestFirst { // stored from the oldest Get(i) to the newest Get(i+n-1)
+		first, second := b.span(b.index(b.logical(i)), n)
+		copy(values[copy(values, first):], second)
+		return values, nil
+	}
+	// in the buffer, values are stored from the oldest Get(i+n-1) to the newest Get(i)
+	first, second := b.span(b.index(i+n-1), n)
+	c := copy(values, first)
+	copy(values[c:], second)
+	for l, r := 0, n-1; l < r; l, r = l+1, r-1 {
+		values[l], values[r] = values[r], values[l]
+	}
+	return values, nil
+}
+
+//Slice returns a copy of the values at the indexes from 'from' included to 'to' excluded, that is [Get(from), ..., Get(to-1)].
+//
+// Negative indexes count from the end, like in Python: Slice(-2, -1) returns [Get(size-2)].
+// It fails with ErrRange if the indexes are out of [-size, size], or not in order.
+func (b *Ring) Slice(from, to int) ([]interface{}, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.slice(from, to)
+}
+
+//slice returns the values from the index 'from' to 'to' (see Slice).
+func (b *ring) slice(from, to int) ([]interface{}, error) {
+	f, t := from, to
+	if f < 0 {
+		f += b.size
+	}
+	if t < 0 {
+		t += b.size
+	}
+	if f < 0 || t > b.size || f > t {
+		return nil, fmt.Errorf("%w: slice [%d:%d], size is %d", ErrRange, from, to, b.size)
+	}
+	if f == t {
+		return []interface{}{}, nil
+	}
+	return b.getRange(f, t-f)
+}
+
+//Values returns a copy of the ring's values, from the oldest to the newest.
+func (b *Ring) Values() []interface{} {
+	if b.snapshots {
+		return append([]interface{}(nil), b.snapshot.Load().values...)
+	}
+	if b.relaxed > 0 {
+		return append([]interface{}(nil), b.relaxedSnapshot().values...)
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.values()
+}
+
+//values returns a copy of the ring's values, from the oldest to the newest.
+func (b *ring) values() []interface{} {
+	values := make([]interface{}, b.size)
+	if b.size == 0 {
+		return values
+	}
+	first, second := b.span(b.index(-1), b.size)
+	n := copy(values, first)
+	copy(values[n:], second)
+	return values
+}
+
 //SetCapacity tries to set the ring's capacity.
 //
 // The ring's content is not altered as a consequence of this operation,
 // therefore the final capacity is kept at least equal to the ring's size.
 //
 // SetCapacity(0) is then equivalent to remove any extra capacity.
+//
+// With lazy allocation (see WithLazyAllocation) growing the capacity does not allocate anything.
 func (b *Ring) SetCapacity(capacity int) {
 	b.lock.Lock()
-	defer b.lock.Unlock()
+	defer b.unlock()
+	b.setCapacity(capacity)
+}
+
+//Reset empties the ring and sets its capacity, at once.
+//
+// The buffer is reused when it can be (see SetCapacity), the sequence number goes on.
+func (b *Ring) Reset(capacity int) {
+	b.lock.Lock()
+	defer b.unlock()
+	b.reset(capacity)
+}
 
+//reset empties the ring and sets its capacity (see Reset).
+func (b *ring) reset(capacity int) {
+	b.remove(b.size)
+	b.setCapacity(capacity)
+}
+
+//setCapacity sets the ring's capacity (see SetCapacity).
+func (b *ring) setCapacity(capacity int) {
 	if capacity < b.size {
 		capacity = b.size
 	}
-	if capacity == len(b.buf) { //nothing to be done
+	if b.pow2 {
+		capacity = powerOfTwo(capacity)
+	}
+	b.capacity = capacity
+	if b.lazy && capacity >= len(b.buf) { //the buffer will grow on demand
 		return
 	}
+	b.resize(capacity)
+}
 
-	nbuf := make([]interface{}, capacity)
+//resize reallocates the buffer to 'n' (>= size) values.
+//
+// When values are split in two pieces, or when the buffer shrinks, they are moved so that the tail is at index 0.
+func (b *ring) resize(n int) {
+	if n == len(b.buf) { //nothing to be done
+		return
+	}
+	if n > len(b.buf) && (b.size == 0 || b.index(-1) <= b.head) {
+		// data is in one piece, and will still be once the buffer is extended:
+		// there is no need to move it, nor the head.
+		b.setBuf(append(b.buf, make([]interface{}, n-len(b.buf))...))
+		return
+	}
+
+	nbuf := make([]interface{}, n)
+	if b.size == 0 {
+		b.setBuf(nbuf)
+		return
+	}
 
 	// now that the new capacity is enough we just copy down the buffer
 
@@ -216,11 +549,11 @@ func (b *Ring) SetCapacity(capacity int) {
 	// 0 to head.
 
 	head := b.head
-	tail := Index(-1, head, b.size, len(b.buf))
+	tail := b.index(-1)
 
 	// we are not going to copy the buffer in the same state (absolute position of head and tail)
 	// instead, we are going to select the simplest solution.
-	if tail < head { //data is in one piece
+	if tail <= head { //data is in one piece
 		copy(nbuf, b.buf[tail:head+1])
 	} else { //two pieces
 		//copy as much as possible to the end of the buf
@@ -228,56 +561,189 @@ func (b *Ring) SetCapacity(capacity int) {
 		//and then from the beginning
 		copy(nbuf[n:], b.buf[:head+1])
 	}
-	b.buf = nbuf
+	b.setBuf(nbuf)
 	b.head = b.size - 1
-	return
+}
+
+//setBuf replaces the buffer, and updates the index mask accordingly.
+func (b *ring) setBuf(buf []interface{}) {
+	b.buf = buf
+	b.mask = 0
+	if b.pow2 && len(buf) > 0 {
+		b.mask = len(buf) - 1
+	}
 }
 
 //Capacity is the max size permitted
+//
+// It does not lock the ring.
 func (b *Ring) Capacity() int {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-	return len(b.buf)
+	return int(b.stats.capacity.Load())
 }
 
 //Size returns the ring's size.
+//
+// It does not lock the ring.
 func (b *Ring) Size() int {
+	return int(b.stats.size.Load())
+}
+
+//Sequence returns the sequence number of the newest value: the number of values added or pushed since the ring's creation.
+//
+// The oldest value's sequence number is then Sequence()-Size()+1.
+func (b *Ring) Sequence() uint64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.seq
+}
+
+//IsEmpty returns true if the ring's size is zero.
+func (b *Ring) IsEmpty() bool {
 	b.lock.RLock()
 	defer b.lock.RUnlock()
-	return b.size
+	return b.size == 0
+}
+
+//IsFull returns true if the ring's size has reached its capacity.
+func (b *Ring) IsFull() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.size >= b.capacity
+}
+
+//Free returns the remaining capacity, that is the number of values that can still be added.
+//
+// Room reserved for slots (see ReserveSlot) is not free.
+func (b *Ring) Free() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.capacity - b.size - b.reserved
+}
+
+//Dropped returns the number of values discarded by Push since the ring's creation.
+//
+// It does not lock the ring.
+func (b *Ring) Dropped() uint64 {
+	return b.stats.dropped.Load()
+}
+
+//unlock publishes the ring's statistics, notifies the watches, releases the write lock, and wakes up waiters (see WaitStrategy).
+func (b *Ring) unlock() {
+	b.publish()
+	for _, w := range b.watches {
+		select {
+		case w <- struct{}{}:
+		default: // an evaluation is already pending
+		}
+	}
+	b.lock.Unlock()
+	b.waiter.Signal()
 }
 
 //private methods
 
-//push  'value' into the ring and discard the oldest one.
-func (b *Ring) push(value interface{}) {
-	b.lock.Lock()
-	defer b.lock.Unlock()
+//pushOne  'value' into the ring and discard the oldest one.
+func (b *ring) pushOne(value interface{}) {
 	if len(b.buf) == 0 || b.size == 0 { // nothing to do
 		return
 	}
-	next := Next(1, b.head, len(b.buf))
+	b.release(1)
+	next := b.next(1)
 	b.buf[next] = value
 	b.head = next
 	// note that the oldest is auto pruned, when size== capacity, but with the size attribute we know it has been discarded
 }
 
-//add 'val' at the Ring's head, it also increases its size.
+//addOne 'val' at the Ring's head, it also increases its size.
 //If the capacity is exhausted (size == capacity) an error is returned.
-func (b *Ring) add(val interface{}) error {
-	if b.size >= len(b.buf) {
+func (b *ring) addOne(val interface{}) error {
+	if b.size+b.reserved >= b.capacity {
 		return ErrFull
 	}
-	b.lock.Lock()
-	defer b.lock.Unlock()
+	if b.sizeOf != nil {
+		bytes := b.sizeOf(val)
+		if b.bytes+bytes > b.budget {
+			return ErrFull
+		}
+		b.bytes += bytes
+	}
+	b.grow(b.size + 1)
 
-	next := Next(1, b.head, len(b.buf))
+	next := b.next(1)
 	b.buf[next] = val
 	b.head = next
 	b.size++ // increase the inner size
+	b.seq++
 	return nil
 }
 
+//put adds 'value' to the head, discarding the oldest value if the ring is full.
+func (b *ring) put(value interface{}) {
+	if b.addOne(value) != nil {
+		b.push(value)
+	}
+}
+
+//evict discards the 'count' oldest values.
+func (b *ring) evict(count int) {
+	if count > b.size {
+		count = b.size
+	}
+	if b.sizeOf != nil {
+		for i := 0; i < count; i++ {
+			b.bytes -= b.sizeOf(b.buf[b.index(-1-i)])
+		}
+	}
+	b.release(count)
+	b.size -= count
+	if b.size == 0 {
+		b.head = -1 //small trick to mark as empty
+		b.bytes = 0
+	}
+}
+
+//truncate discards the 'count' newest values.
+func (b *ring) truncate(count int) {
+	if count > b.size {
+		count = b.size
+	}
+	for i := 0; i < count; i++ {
+		if b.sizeOf != nil {
+			b.bytes -= b.sizeOf(b.buf[b.head])
+		}
+		b.buf[b.head] = nil
+		b.head = b.next(-1)
+		b.size--
+	}
+	if b.size == 0 {
+		b.head = -1
+		b.bytes = 0
+	}
+}
+
+//release clears the slots of the 'count' oldest values, so that they can be garbage collected.
+func (b *ring) release(count int) {
+	if count <= 0 {
+		return
+	}
+	first, second := b.span(b.index(-1), count)
+	for i := range first {
+		first[i] = nil
+	}
+	for i := range second {
+		second[i] = nil
+	}
+}
+
+//span returns the buffer's slots from the absolute index 'start' to 'start+n' (wrapping around),
+// as at most two slices.
+func (b *ring) span(start, n int) (first, second []interface{}) {
+	if start+n <= len(b.buf) {
+		return b.buf[start : start+n], nil
+	}
+	return b.buf[start:], b.buf[:start+n-len(b.buf)]
+}
+
 //util functions.
 
 // Next computes the next index for a ring buffer
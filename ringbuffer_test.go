@@ -1,7 +1,9 @@
 package ringbuffer
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 )
 
@@ -190,7 +192,7 @@ func TestAddAll(t *testing.T) {
 	b = New(3)
 
 	err = b.Add(0, 1, 2, 3)
-	if err != ErrFull {
+	if !errors.Is(err, ErrFull) {
 		t.Fatalf("should have failed with FullError, got %v", err)
 	}
 	if b.Size() != 0 {
@@ -317,5 +319,213 @@ func print(b *Ring) string {
 	} else { //two pieces
 		return fmt.Sprintf("*%v*  %v   *%v*", b.buf[:latest+1], b.buf[latest+1:end], b.buf[end:])
 	}
-	return ""
+}
+
+func TestRelease(t *testing.T) {
+	b := New(5)
+	b.Add(1, 2, 3)
+	b.Push(4)
+	b.Push(5, 6)
+	b.Remove(1)
+	// buffer   indexes   0 1 2 3 4
+	// circular indexes   0 x x x 1
+	for i, v := range []interface{}{6, nil, nil, nil, 5} {
+		if b.buf[i] != v {
+			t.Fatalf("slot %v should contain %v, got %v", i, v, b.buf[i])
+		}
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	b := New(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				b.Add(j)
+				b.TryAdd(j)
+			}
+		}()
+	}
+	wg.Wait()
+	if b.Size() != b.Capacity() {
+		t.Fatalf("Invalid size %v, expecting %v", b.Size(), b.Capacity())
+	}
+	if b.TryAdd(0) {
+		t.Fatalf("TryAdd should fail on a full ring")
+	}
+}
+
+func BenchmarkPushAll(b *testing.B) {
+	r := New(1000)
+	vals := make([]interface{}, 100)
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Push(vals...)
+	}
+}
+
+func TestIncreaseInPlace(t *testing.T) {
+	b := New(6)
+	b.head = 0 //fake an offset
+	b.Add(1, 2, 3, 4)
+	b.SetCapacity(10)
+	if b.head != 4 || len(b.buf) != 10 {
+		t.Fatalf("contiguous values should not move: head=%v len=%v", b.head, len(b.buf))
+	}
+	b.Add(5, 6, 7, 8, 9, 10)
+	x := New(10)
+	x.Add(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	if !equals(b, x) {
+		t.Errorf("increase failed.\nreal %s\ngold %s", print(b), print(x))
+	}
+}
+
+func TestGetRange(t *testing.T) {
+	b := New(5)
+	if _, err := b.GetRange(0, 1); err != ErrEmpty {
+		t.Fatalf("should have failed with ErrEmpty, got %v", err)
+	}
+	b.Add(1, 2, 3, 4)
+	b.Push(5, 6) // values overlap the end
+	for i := -6; i < 6; i++ {
+		for n := 0; n <= b.Size()-(i+8)%4; n++ {
+			values, err := b.GetRange(i, n)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			for k, v := range values {
+				if x, _ := b.Get(i + k); x != v {
+					t.Fatalf("GetRange(%v, %v)[%v] = %v, expecting %v", i, n, k, v, x)
+				}
+			}
+		}
+	}
+	if _, err := b.GetRange(-1, 2); !errors.Is(err, ErrRange) {
+		t.Fatalf("should have failed with ErrRange, got %v", err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	b := New(3)
+	b.Add(1, 2, 3)
+	b.Reset(5)
+	if b.Size() != 0 || b.Capacity() != 5 || b.Sequence() != 3 {
+		t.Fatalf("Invalid reset ring: size %v, capacity %v, sequence %v", b.Size(), b.Capacity(), b.Sequence())
+	}
+	b.Add(4, 5, 6, 7, 8)
+	b.Reset(2)
+	b.Add(9, 10)
+	if fmt.Sprint(b.Values()) != "[9 10]" || b.Capacity() != 2 {
+		t.Fatalf("Invalid reset ring %v, capacity %v", b.Values(), b.Capacity())
+	}
+	u := NewUnlocked(2, WithPowerOfTwo())
+	u.Add(1)
+	u.Reset(3)
+	if u.Size() != 0 || u.Capacity() != 4 {
+		t.Fatalf("Invalid reset ring: size %v, capacity %v", u.Size(), u.Capacity())
+	}
+}
+
+func TestOldestNewest(t *testing.T) {
+	b := New(3)
+	if _, err := b.Oldest(); err != ErrEmpty {
+		t.Fatalf("Oldest should fail on an empty ring, got %v", err)
+	}
+	if _, err := b.Newest(); err != ErrEmpty {
+		t.Fatalf("Newest should fail on an empty ring, got %v", err)
+	}
+	b.Add(1, 2, 3)
+	b.Push(4)
+	if o, _ := b.Oldest(); o != 2 {
+		t.Fatalf("Oldest should return %v, got %v", 2, o)
+	}
+	if n, _ := b.Newest(); n != 4 {
+		t.Fatalf("Newest should return %v, got %v", 4, n)
+	}
+	u := NewUnlocked(3, WithOldestFirst())
+	u.Add(1, 2)
+	if o, _ := u.Oldest(); o != 1 {
+		t.Fatalf("Oldest should ignore the index order, got %v", o)
+	}
+	if n, _ := u.Newest(); n != 2 {
+		t.Fatalf("Newest should ignore the index order, got %v", n)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	b := New(5)
+	if values, err := b.Slice(0, 0); err != nil || len(values) != 0 {
+		t.Fatalf("An empty slice of an empty ring should succeed, got %v, %v", values, err)
+	}
+	b.Add(1, 2, 3, 4)
+	for _, c := range []struct {
+		from, to int
+		want     string
+	}{
+		{0, 4, "[4 3 2 1]"},
+		{1, 3, "[3 2]"},
+		{-2, -1, "[2]"},
+		{0, -1, "[4 3 2]"},
+		{-4, 4, "[4 3 2 1]"},
+		{2, 2, "[]"},
+	} {
+		if values, err := b.Slice(c.from, c.to); err != nil || fmt.Sprint(values) != c.want {
+			t.Fatalf("Slice(%v, %v) should return %v, got %v, %v", c.from, c.to, c.want, values, err)
+		}
+	}
+	for _, c := range [][2]int{{0, 5}, {-5, 1}, {3, 1}} {
+		if _, err := b.Slice(c[0], c[1]); !errors.Is(err, ErrRange) {
+			t.Fatalf("Slice(%v, %v) should have failed with ErrRange, got %v", c[0], c[1], err)
+		}
+	}
+}
+
+func TestFree(t *testing.T) {
+	b := New(3)
+	if !b.IsEmpty() || b.IsFull() || b.Free() != 3 {
+		t.Fatalf("Invalid empty/full/free %v/%v/%v, expecting %v/%v/%v", b.IsEmpty(), b.IsFull(), b.Free(), true, false, 3)
+	}
+	b.Add(1, 2)
+	if b.IsEmpty() || b.IsFull() || b.Free() != 1 {
+		t.Fatalf("Invalid empty/full/free %v/%v/%v, expecting %v/%v/%v", b.IsEmpty(), b.IsFull(), b.Free(), false, false, 1)
+	}
+	b.Add(3)
+	if b.IsEmpty() || !b.IsFull() || b.Free() != 0 {
+		t.Fatalf("Invalid empty/full/free %v/%v/%v, expecting %v/%v/%v", b.IsEmpty(), b.IsFull(), b.Free(), false, true, 0)
+	}
+}
+
+func TestMultiGet(t *testing.T) {
+	b := New(5)
+	if b.GetOrDefault(0, "none") != "none" {
+		t.Fatalf("GetOrDefault should return the default value on an empty ring")
+	}
+	if _, err := b.MultiGet(0); err != ErrEmpty {
+		t.Fatalf("should have failed with ErrEmpty, got %v", err)
+	}
+	b.Add(1, 2, 3)
+	if b.GetOrDefault(0, "none") != 3 {
+		t.Fatalf("GetOrDefault(0) = %v, expecting %v", b.GetOrDefault(0, "none"), 3)
+	}
+	values, err := b.MultiGet(0, -1, 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Fatalf("Invalid values %v, expecting %v", values, []interface{}{3, 1, 2})
+	}
+}
+
+func ExampleRing_PushReturning() {
+	buf := New(5)
+	buf.Add(1, 2, 3)
+	evicted, ok := buf.PushReturning(4)
+	fmt.Println(evicted, ok)
+	//Output: 1 true
 }
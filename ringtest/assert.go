@@ -0,0 +1,48 @@
+package ringtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//AssertEqual fails the test if 'got' and 'want' have different capacities, or different values.
+//
+// The failure shows a diff of the values, and the internal state of both rings (see ringbuffer.Ring.GoString).
+func AssertEqual(t testing.TB, got, want Ring) {
+	t.Helper()
+	if got.Capacity() != want.Capacity() || !sameValues(got.Values(), want.Values()) {
+		t.Fatalf("rings differ, capacity %d, expecting %d:\n%s\ngot  %#v\nwant %#v",
+			got.Capacity(), want.Capacity(), Diff(got.Values(), want.Values()), got, want)
+	}
+}
+
+//AssertContents fails the test if 'r' does not hold exactly 'values', from the oldest to the newest.
+//
+// The failure shows a diff of the values, and the internal state of the ring (see ringbuffer.Ring.GoString).
+func AssertContents(t testing.TB, r Ring, values ...interface{}) {
+	t.Helper()
+	if got := r.Values(); !sameValues(got, values) {
+		t.Fatalf("unexpected values:\n%s\ngot %#v", Diff(got, values), r)
+	}
+}
+
+//Diff returns a readable diff of two value lists, from the oldest to the newest:
+// a line per index, prefixed by '-' for the values of 'got' that differ, and by '+' for the expected ones.
+func Diff(got, want []interface{}) string {
+	var s strings.Builder
+	for i := 0; i < max(len(got), len(want)); i++ {
+		switch {
+		case i >= len(want):
+			fmt.Fprintf(&s, "- %d: %v\n", i, got[i])
+		case i >= len(got):
+			fmt.Fprintf(&s, "+ %d: %v\n", i, want[i])
+		case !reflect.DeepEqual(got[i], want[i]):
+			fmt.Fprintf(&s, "- %d: %v\n+ %d: %v\n", i, got[i], i, want[i])
+		default:
+			fmt.Fprintf(&s, "  %d: %v\n", i, got[i])
+		}
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
@@ -0,0 +1,42 @@
+package ringtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ericaro/ringbuffer"
+)
+
+//recorder is a testing.TB recording the failures, instead of stopping the test.
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failure = fmt.Sprintf(format, args...)
+}
+
+func TestAssert(t *testing.T) {
+	b := ringbuffer.New(3)
+	b.Add(1, 2, 3)
+	b.Push(4)
+	AssertContents(t, b, 2, 3, 4)
+	m := NewModel(3)
+	m.Add(2, 3, 4)
+	AssertEqual(t, b, m)
+
+	r := &recorder{TB: t}
+	AssertContents(r, b, 2, 5)
+	if !strings.HasPrefix(r.failure, "unexpected values:\n  0: 2\n- 1: 3\n+ 1: 5\n- 2: 4\ngot &ringbuffer.Ring{head:0, size:3") {
+		t.Fatalf("Invalid failure %q", r.failure)
+	}
+	r.failure = ""
+	AssertEqual(r, b, NewModel(4))
+	if !strings.HasPrefix(r.failure, "rings differ, capacity 3, expecting 4:\n- 0: 2") {
+		t.Fatalf("Invalid failure %q", r.failure)
+	}
+}
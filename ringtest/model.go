@@ -0,0 +1,96 @@
+// Copyright 2014 @ericaro. All rights reserved.
+// Use of this source code is governed by a Apache License, Version 2.0.
+
+// Package ringtest checks ring buffers against a reference model.
+//
+// It lets users embedding custom configurations (options, policies, wrappers) verify that their rings
+// still behave like a ring: random operation sequences are applied both to the ring and to a Model,
+// a plain slice, and their results compared after each step.
+package ringtest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ericaro/ringbuffer"
+)
+
+//Ring is the ring under test, ringbuffer.Ring and ringbuffer.Unlocked implement it.
+type Ring interface {
+	ringbuffer.Buffer
+	Values() []interface{}
+}
+
+//Model is the reference implementation of a Ring: a plain slice, from the oldest to the newest value.
+type Model struct {
+	values   []interface{}
+	capacity int
+}
+
+//NewModel creates an empty model of 'capacity' values.
+func NewModel(capacity int) *Model {
+	return &Model{capacity: capacity}
+}
+
+//Add appends 'values', unless they do not fit: it fails with ringbuffer.ErrFull then.
+func (m *Model) Add(values ...interface{}) error {
+	if len(m.values)+len(values) > m.capacity {
+		return fmt.Errorf("%w: cannot add %d values to %d", ringbuffer.ErrFull, len(values), len(m.values))
+	}
+	m.values = append(m.values, values...)
+	return nil
+}
+
+//Push appends 'values', removing as many of the oldest values: the size does not change.
+func (m *Model) Push(values ...interface{}) {
+	n := len(m.values)
+	m.values = append(m.values, values...)
+	m.values = append([]interface{}(nil), m.values[len(m.values)-n:]...)
+}
+
+//Remove removes the 'count' oldest values, all of them if there are fewer.
+func (m *Model) Remove(count int) {
+	if count <= 0 {
+		return
+	}
+	m.values = m.values[min(count, len(m.values)):]
+}
+
+//Get returns the value at index 'i': 0 is the newest, -1 the oldest, and indexes wrap around.
+//
+// It fails with ringbuffer.ErrEmpty if the model is empty.
+func (m *Model) Get(i int) (interface{}, error) {
+	n := len(m.values)
+	if n == 0 {
+		return 0, ringbuffer.ErrEmpty
+	}
+	i %= n
+	if i < 0 {
+		i += n
+	}
+	return m.values[n-1-i], nil
+}
+
+//Values returns a copy of the values, from the oldest to the newest.
+func (m *Model) Values() []interface{} {
+	return append(make([]interface{}, 0, len(m.values)), m.values...)
+}
+
+//Size returns the number of values.
+func (m *Model) Size() int { return len(m.values) }
+
+//Capacity returns the max number of values.
+func (m *Model) Capacity() int { return m.capacity }
+
+//sameError tells whether 'got' and 'want' are both nil, or match the same ring error.
+func sameError(got, want error) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	for _, target := range []error{ringbuffer.ErrFull, ringbuffer.ErrEmpty, ringbuffer.ErrRange} {
+		if errors.Is(want, target) {
+			return errors.Is(got, target)
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package ringtest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+)
+
+//OpKind is the kind of an operation on a ring.
+type OpKind int
+
+const (
+	OpAdd    OpKind = iota // Add(Values...)
+	OpPush                 // Push(Values...)
+	OpRemove               // Remove(Count)
+	OpGet                  // Get(Index)
+)
+
+//Op is an operation on a ring.
+type Op struct {
+	Kind   OpKind
+	Values []interface{} // for OpAdd and OpPush
+	Count  int           // for OpRemove
+	Index  int           // for OpGet
+}
+
+func (op Op) String() string {
+	switch op.Kind {
+	case OpAdd, OpPush:
+		args := make([]string, len(op.Values))
+		for i, v := range op.Values {
+			args[i] = fmt.Sprintf("%#v", v)
+		}
+		name := "Add"
+		if op.Kind == OpPush {
+			name = "Push"
+		}
+		return fmt.Sprintf("%s(%s)", name, strings.Join(args, ", "))
+	case OpRemove:
+		return fmt.Sprintf("Remove(%d)", op.Count)
+	case OpGet:
+		return fmt.Sprintf("Get(%d)", op.Index)
+	}
+	return fmt.Sprintf("Op(%d)", op.Kind)
+}
+
+//Apply applies the operation to 'r', and returns its results.
+func (op Op) Apply(r Ring) (v interface{}, err error) {
+	switch op.Kind {
+	case OpAdd:
+		err = r.Add(op.Values...)
+	case OpPush:
+		r.Push(op.Values...)
+	case OpRemove:
+		r.Remove(op.Count)
+	case OpGet:
+		v, err = r.Get(op.Index)
+	}
+	return v, err
+}
+
+//RandomOps returns 'n' random operations for a ring of 'capacity', using 'rng'.
+//
+// The values are consecutive ints, so that every value is unique.
+func RandomOps(rng *rand.Rand, n, capacity int) []Op {
+	ops := make([]Op, n)
+	next := 0
+	values := func() []interface{} {
+		values := make([]interface{}, rng.Intn(capacity+2))
+		for i := range values {
+			values[i] = next
+			next++
+		}
+		return values
+	}
+	for i := range ops {
+		switch kind := OpKind(rng.Intn(4)); kind {
+		case OpAdd, OpPush:
+			ops[i] = Op{Kind: kind, Values: values()}
+		case OpRemove:
+			ops[i] = Op{Kind: kind, Count: rng.Intn(capacity+2) - 1}
+		case OpGet:
+			ops[i] = Op{Kind: kind, Index: rng.Intn(2*capacity+3) - capacity - 1}
+		}
+	}
+	return ops
+}
+
+//Check applies 'ops' to 'r' and to a model of the same capacity, comparing their results and their states after each operation.
+//
+// 'r' must be empty. The error describes the first difference, and the operations leading to it.
+func Check(r Ring, ops []Op) error {
+	m := NewModel(r.Capacity())
+	for i, op := range ops {
+		v, err := op.Apply(r)
+		mv, merr := op.Apply(m)
+		switch {
+		case !sameError(err, merr):
+			return mismatch(ops[:i+1], "error %v, expecting %v", err, merr)
+		case merr == nil && !reflect.DeepEqual(v, mv):
+			return mismatch(ops[:i+1], "returned %v, expecting %v", v, mv)
+		case r.Size() != m.Size():
+			return mismatch(ops[:i+1], "size %d, expecting %d", r.Size(), m.Size())
+		case r.Capacity() != m.Capacity():
+			return mismatch(ops[:i+1], "capacity %d, expecting %d", r.Capacity(), m.Capacity())
+		case !sameValues(r.Values(), m.Values()):
+			return mismatch(ops[:i+1], "values %v, expecting %v", r.Values(), m.Values())
+		}
+	}
+	return nil
+}
+
+//mismatch describes a difference after 'ops'.
+func mismatch(ops []Op, format string, args ...interface{}) error {
+	steps := make([]string, len(ops))
+	for i, op := range ops {
+		steps[i] = op.String()
+	}
+	return fmt.Errorf("after %s: %s", strings.Join(steps, "; "), fmt.Sprintf(format, args...))
+}
+
+//sameValues compares 'got' and 'want', a nil slice being empty.
+func sameValues(got, want []interface{}) bool {
+	return len(got) == len(want) && (len(got) == 0 || reflect.DeepEqual(got, want))
+}
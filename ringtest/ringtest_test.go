@@ -0,0 +1,59 @@
+package ringtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ericaro/ringbuffer"
+)
+
+func TestModel(t *testing.T) {
+	m := NewModel(3)
+	if _, err := m.Get(0); err != ringbuffer.ErrEmpty {
+		t.Fatalf("Get should fail on an empty model, got %v", err)
+	}
+	m.Add(1, 2)
+	if err := m.Add(3, 4); err == nil {
+		t.Fatalf("Add should fail over capacity")
+	}
+	m.Push(3, 4, 5)
+	if v, _ := m.Get(-1); v != 4 {
+		t.Fatalf("Get(-1) should return %v, got %v", 4, v)
+	}
+	AssertContents(t, m, 4, 5)
+}
+
+func TestRun(t *testing.T) {
+	Run(t, 1, 200, 8, func(capacity int) Ring { return ringbuffer.New(capacity) })
+	Run(t, 2, 200, 8, func(capacity int) Ring { return ringbuffer.NewUnlocked(capacity) })
+	Run(t, 3, 200, 8, func(capacity int) Ring { return ringbuffer.New(capacity, ringbuffer.WithLazyAllocation()) })
+	Run(t, 4, 200, 8, func(capacity int) Ring { return ringbuffer.New(capacity, ringbuffer.WithSnapshots()) })
+}
+
+func TestRunConcurrent(t *testing.T) {
+	RunConcurrent(t, ringbuffer.New(16), 8, 1000)
+}
+
+//lossy is a broken ring, forgetting to add a single value.
+type lossy struct{ *ringbuffer.Ring }
+
+func (l lossy) Add(values ...interface{}) error {
+	if len(values) == 1 {
+		return nil
+	}
+	return l.Ring.Add(values...)
+}
+
+func TestCheck(t *testing.T) {
+	ops := []Op{{Kind: OpAdd, Values: []interface{}{1, 2}}, {Kind: OpAdd, Values: []interface{}{3}}, {Kind: OpGet}}
+	if err := Check(ringbuffer.New(3), ops); err != nil {
+		t.Fatal(err)
+	}
+	err := Check(lossy{ringbuffer.New(3)}, ops)
+	if err == nil || !strings.HasPrefix(err.Error(), "after Add(1, 2); Add(3): size 2, expecting 3") {
+		t.Fatalf("Check should report the broken ring, got %v", err)
+	}
+	if checkProducers([]interface{}{[2]int{0, 1}, [2]int{0, 0}}, 2) == nil {
+		t.Fatalf("Values out of order should be reported")
+	}
+}
@@ -0,0 +1,76 @@
+package ringtest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+//Run checks 'runs' random operation sequences (see Check) on rings created by 'newRing',
+//with random capacities up to 'maxCapacity'.
+//
+// It is deterministic for a given 'seed', which is reported on failure.
+func Run(t testing.TB, seed int64, runs, maxCapacity int, newRing func(capacity int) Ring) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	for run := 0; run < runs; run++ {
+		capacity := rng.Intn(maxCapacity + 1)
+		if err := Check(newRing(capacity), RandomOps(rng, 50, capacity)); err != nil {
+			t.Fatalf("seed %d, run %d, capacity %d: %v", seed, run, capacity, err)
+		}
+	}
+}
+
+//RunConcurrent checks a ring shared by 'producers' goroutines, each adding (or pushing when it is full) 'n' values.
+//
+// A concurrent ring cannot be compared to a model step by step, the final state is checked instead:
+// the ring must not be over capacity, and must hold unique values, in the order of each producer.
+func RunConcurrent(t testing.TB, r Ring, producers, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				v := [2]int{p, i}
+				if r.Add(v) != nil {
+					r.Push(v)
+				}
+				r.Get(i)
+			}
+		}(p)
+	}
+	wg.Wait()
+	if err := checkProducers(r.Values(), r.Capacity()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//checkProducers checks the final state of a ring filled by RunConcurrent.
+func checkProducers(values []interface{}, capacity int) error {
+	if len(values) > capacity {
+		return fmt.Errorf("%d values over capacity %d", len(values), capacity)
+	}
+	last := make(map[int]int)
+	for _, v := range values {
+		pv, ok := v.([2]int)
+		if !ok {
+			return fmt.Errorf("unexpected value %v", v)
+		}
+		if i, seen := last[pv[0]]; seen && i >= pv[1] {
+			return fmt.Errorf("value %d of producer %d after its value %d: %v", pv[1], pv[0], i, sorted(values))
+		}
+		last[pv[0]] = pv[1]
+	}
+	return nil
+}
+
+//sorted returns the values sorted by producer, for readable reports.
+func sorted(values []interface{}) []interface{} {
+	values = append([]interface{}(nil), values...)
+	sort.SliceStable(values, func(i, j int) bool { return values[i].([2]int)[0] < values[j].([2]int)[0] })
+	return values
+}
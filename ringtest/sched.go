@@ -0,0 +1,162 @@
+package ringtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+//ErrDeadlock is the error returned by Scheduler.Run when every remaining thread is blocked.
+var ErrDeadlock = errors.New("deadlock: every thread is blocked")
+
+//Scheduler runs concurrent threads of steps one step at a time, in a chosen order, to force interleavings:
+// a rare race is then reproduced by replaying its schedule, instead of hoping that -race catches it.
+//
+// It is a ringbuffer.WaitStrategy: rings using it (see ringbuffer.WithWaitStrategy) hand control back to the scheduler
+// when a thread blocks (AddWait, PopWait, ...), the thread being runnable again once the ring is signaled.
+// Only one thread runs at a time, and a Scheduler runs once.
+type Scheduler struct {
+	threads  []*thread
+	current  *thread
+	back     chan struct{} // the running thread hands control back
+	deadlock bool          // blocked threads are resumed, their Wait failing with ErrDeadlock
+}
+
+//thread is a sequence of steps run by a Scheduler.
+type thread struct {
+	steps   []func()
+	resume  chan struct{}
+	blocked bool
+	done    bool
+}
+
+//NewScheduler creates a scheduler without threads.
+func NewScheduler() *Scheduler {
+	return &Scheduler{back: make(chan struct{})}
+}
+
+//Go adds a thread running 'steps' in order. It returns the thread's index, as used in schedules.
+func (s *Scheduler) Go(steps ...func()) int {
+	s.threads = append(s.threads, &thread{steps: steps, resume: make(chan struct{}), done: len(steps) == 0})
+	return len(s.threads) - 1
+}
+
+//Run runs the threads to completion, choosing with 'pick' the next thread to run a step among the runnable ones.
+//
+// It returns the schedule, the index of the thread that ran each step, to replay it (see Replay).
+// If every remaining thread is blocked it fails with ErrDeadlock, once the threads are finished anyway.
+func (s *Scheduler) Run(pick func(runnable []int) int) (schedule []int, err error) {
+	for i, t := range s.threads {
+		if !t.done {
+			go s.run(s.threads[i])
+		}
+	}
+	for {
+		var runnable []int
+		blocked := false
+		for i, t := range s.threads {
+			switch {
+			case t.done:
+			case t.blocked && !s.deadlock:
+				blocked = true
+			default:
+				runnable = append(runnable, i)
+			}
+		}
+		if len(runnable) == 0 {
+			if !blocked {
+				break
+			}
+			s.deadlock, err = true, ErrDeadlock
+			continue
+		}
+		k := pick(runnable)
+		s.current = s.threads[k]
+		s.current.blocked = false
+		schedule = append(schedule, k)
+		s.current.resume <- struct{}{}
+		<-s.back
+	}
+	return schedule, err
+}
+
+//run runs the thread's steps, when resumed by the scheduler.
+func (s *Scheduler) run(t *thread) {
+	for i, step := range t.steps {
+		<-t.resume
+		step()
+		t.done = i == len(t.steps)-1
+		s.back <- struct{}{}
+	}
+}
+
+//Wait hands control back to the scheduler until 'ready' returns true (see ringbuffer.WaitStrategy).
+//
+// It fails with ErrDeadlock if every thread is blocked, and returns ctx.Err() if the context is done first.
+func (s *Scheduler) Wait(ctx context.Context, ready func() bool) error {
+	for !ready() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if s.deadlock {
+			return ErrDeadlock
+		}
+		t := s.current
+		t.blocked = true
+		s.back <- struct{}{}
+		<-t.resume
+	}
+	return nil
+}
+
+//Signal makes the blocked threads runnable again (see ringbuffer.WaitStrategy).
+func (s *Scheduler) Signal() {
+	for _, t := range s.threads {
+		t.blocked = false
+	}
+}
+
+//Random returns a 'pick' function choosing the next thread at random, using 'rng'.
+func Random(rng *rand.Rand) func(runnable []int) int {
+	return func(runnable []int) int {
+		return runnable[rng.Intn(len(runnable))]
+	}
+}
+
+//Replay returns a 'pick' function following 'schedule', and then choosing the first runnable thread.
+//
+// A thread of the schedule that is not runnable is skipped.
+func Replay(schedule []int) func(runnable []int) int {
+	return func(runnable []int) int {
+		for len(schedule) > 0 {
+			k := schedule[0]
+			schedule = schedule[1:]
+			for _, r := range runnable {
+				if r == k {
+					return k
+				}
+			}
+		}
+		return runnable[0]
+	}
+}
+
+//Explore runs 'runs' random schedules of the threads set up by 'setup', which returns a check of the final state.
+//
+// It is deterministic for a given 'seed'. The error reports the first failing schedule, to be replayed.
+func Explore(seed int64, runs int, setup func(s *Scheduler) (check func() error)) error {
+	rng := rand.New(rand.NewSource(seed))
+	for run := 0; run < runs; run++ {
+		s := NewScheduler()
+		check := setup(s)
+		schedule, err := s.Run(Random(rng))
+		if err == nil {
+			err = check()
+		}
+		if err != nil {
+			return fmt.Errorf("seed %d, run %d, schedule %v: %w", seed, run, schedule, err)
+		}
+	}
+	return nil
+}
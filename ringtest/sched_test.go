@@ -0,0 +1,80 @@
+package ringtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ericaro/ringbuffer"
+)
+
+func TestSchedulerBlocking(t *testing.T) {
+	err := Explore(1, 100, func(s *Scheduler) func() error {
+		b := ringbuffer.New(1, ringbuffer.WithWaitStrategy(s))
+		var got []interface{}
+		var producer, consumer []func()
+		for i := 0; i < 3; i++ {
+			producer = append(producer, func() { b.AddWait(context.Background(), i) })
+			consumer = append(consumer, func() {
+				v, _ := b.PopWait(context.Background())
+				got = append(got, v)
+			})
+		}
+		s.Go(producer...)
+		s.Go(consumer...)
+		return func() error {
+			if fmt.Sprint(got) != "[0 1 2]" {
+				return fmt.Errorf("consumed %v", got)
+			}
+			return nil
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//checkThenAdd sets up two threads adding a value if the ring is not full, in two steps: a race.
+func checkThenAdd(s *Scheduler) func() error {
+	b := ringbuffer.New(1)
+	var errs []error
+	for i := 0; i < 2; i++ {
+		full := false
+		s.Go(func() { full = b.IsFull() }, func() {
+			if !full {
+				errs = append(errs, b.Add(i))
+			}
+		})
+	}
+	return func() error { return errors.Join(errs...) }
+}
+
+func TestSchedulerRace(t *testing.T) {
+	err := Explore(1, 100, checkThenAdd)
+	if !errors.Is(err, ringbuffer.ErrFull) {
+		t.Fatalf("The race should have been found, got %v", err)
+	}
+	s := NewScheduler()
+	check := checkThenAdd(s)
+	schedule, _ := s.Run(Replay([]int{0, 1, 0, 1}))
+	if fmt.Sprint(schedule) != "[0 1 0 1]" || !errors.Is(check(), ringbuffer.ErrFull) {
+		t.Fatalf("The race should be replayed, got %v", schedule)
+	}
+	s = NewScheduler()
+	check = checkThenAdd(s)
+	s.Run(Replay([]int{0, 0, 1, 1}))
+	if err := check(); err != nil {
+		t.Fatalf("A sequential schedule should not fail, got %v", err)
+	}
+}
+
+func TestSchedulerDeadlock(t *testing.T) {
+	s := NewScheduler()
+	b := ringbuffer.New(1, ringbuffer.WithWaitStrategy(s))
+	var err error
+	s.Go(func() { _, err = b.PopWait(context.Background()) })
+	if _, runErr := s.Run(Replay(nil)); runErr != ErrDeadlock || err != ErrDeadlock {
+		t.Fatalf("Run should fail with ErrDeadlock, got %v, %v", runErr, err)
+	}
+}
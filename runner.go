@@ -0,0 +1,102 @@
+package ringbuffer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+//running is the number of goroutines started by Runners, and still running.
+var running atomic.Int64
+
+//Running returns the number of goroutines started by the package's background features (Batcher, WorkQueue, Watch, ...),
+//and still running.
+//
+// Embedders can check that it is back to zero once they have stopped everything, proving that nothing leaked.
+func Running() int {
+	return int(running.Load())
+}
+
+//Runner manages the goroutines of a background feature: Start starts them, Stop asks them to stop, and Wait waits for them.
+//
+// The zero Runner is ready to use. It is safe for concurrent use.
+type Runner struct {
+	lock    sync.Mutex
+	stop    chan struct{} // closed by Stop
+	idle    chan struct{} // closed when no goroutine is running
+	n       int
+	stopped bool
+}
+
+//init creates the channels of a zero Runner.
+func (r *Runner) init() {
+	if r.stop == nil {
+		r.stop, r.idle = make(chan struct{}), make(chan struct{})
+		close(r.idle)
+	}
+}
+
+//Start runs 'f' in a new goroutine. 'stop' is closed when the runner is stopped, 'f' should return then.
+//
+// It fails with ErrClosed once the runner is stopped.
+func (r *Runner) Start(f func(stop <-chan struct{})) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.init()
+	if r.stopped {
+		return ErrClosed
+	}
+	if r.n == 0 {
+		r.idle = make(chan struct{})
+	}
+	r.n++
+	running.Add(1)
+	go func(stop <-chan struct{}) {
+		defer r.done()
+		f(stop)
+	}(r.stop)
+	return nil
+}
+
+//done accounts for a goroutine that returned.
+func (r *Runner) done() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.n--
+	running.Add(-1)
+	if r.n == 0 {
+		close(r.idle)
+	}
+}
+
+//Stop asks the goroutines to stop, and prevents new ones from starting. It can be called several times.
+func (r *Runner) Stop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.init()
+	if !r.stopped {
+		r.stopped = true
+		close(r.stop)
+	}
+}
+
+//Wait waits until no goroutine is running, or 'ctx' is done (then it returns ctx.Err()).
+func (r *Runner) Wait(ctx context.Context) error {
+	r.lock.Lock()
+	r.init()
+	idle := r.idle
+	r.lock.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//Len returns the number of goroutines running.
+func (r *Runner) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.n
+}
@@ -0,0 +1,59 @@
+package ringbuffer
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRunner(t *testing.T) {
+	var r Runner
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("An idle runner should not wait, got %v", err)
+	}
+	release := make(chan struct{})
+	r.Start(func(stop <-chan struct{}) { <-stop })
+	r.Start(func(stop <-chan struct{}) { <-release })
+	if r.Len() != 2 {
+		t.Fatalf("Invalid number of goroutines %v", r.Len())
+	}
+	r.Stop()
+	r.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Wait should time out, got %v", err)
+	}
+	close(release)
+	if err := r.Wait(context.Background()); err != nil || r.Len() != 0 {
+		t.Fatalf("Wait should return once the goroutines are done, got %v, %v", err, r.Len())
+	}
+	if err := r.Start(func(<-chan struct{}) {}); err != ErrClosed {
+		t.Fatalf("Start should fail once stopped, got %v", err)
+	}
+}
+
+func TestNoLeak(t *testing.T) {
+	before := Running()
+	batcher := NewBatcher(10, 5, time.Hour, func([]interface{}) {})
+	batcher.Add(1)
+	queue := NewWorkQueue(10, 4, FullReject)
+	queue.Submit(func() {})
+	b := New(3)
+	stop := b.Watch(func(*Ring) float64 { return 0 }, 1, func(float64) {})
+	b.Add(1)
+	recorder := NewFlightRecorder(b, nil)
+	recorder.Watch(os.Interrupt)
+	if Running() != before+7 {
+		t.Fatalf("Invalid number of goroutines %v", Running()-before)
+	}
+
+	batcher.Close()
+	queue.Shutdown(context.Background())
+	stop()
+	recorder.Stop()
+	if Running() != before {
+		t.Fatalf("%d goroutines leaked", Running()-before)
+	}
+}
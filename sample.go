@@ -0,0 +1,117 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+//Sample is a timestamped numeric value, the element of timestamped rings.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+//TimeRange is a time interval, from From to To.
+type TimeRange struct {
+	From, To time.Time
+}
+
+//Duration returns the range's duration.
+func (r TimeRange) Duration() time.Duration {
+	return r.To.Sub(r.From)
+}
+
+//number returns the numeric value of 'v': a Go number, or a Sample's value.
+func number(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case float32:
+		return float64(x), true
+	case int:
+		return float64(x), true
+	case int8:
+		return float64(x), true
+	case int16:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint:
+		return float64(x), true
+	case uint8:
+		return float64(x), true
+	case uint16:
+		return float64(x), true
+	case uint32:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case Sample:
+		return x.Value, true
+	}
+	return 0, false
+}
+
+//At returns the value at time 't', linearly interpolated between the surrounding samples.
+//
+// The ring must hold Samples, from the oldest to the newest time: the samples are binary searched,
+// it fails with ErrNotNumeric if it meets another value, and with ErrRange if 't' is out of the samples' time range.
+func (b *Ring) At(t time.Time) (float64, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if b.size == 0 {
+		return 0, ErrEmpty
+	}
+	var err error
+	sample := func(k int) Sample { // the k-th oldest sample
+		v := b.buf[b.index(b.size-1-k)]
+		s, ok := v.(Sample)
+		if !ok && err == nil {
+			err = fmt.Errorf("%w: %T is not a Sample", ErrNotNumeric, v)
+		}
+		return s
+	}
+	k := sort.Search(b.size, func(k int) bool { return !sample(k).Time.Before(t) })
+	if err != nil {
+		return 0, err
+	}
+	if k == b.size || (k == 0 && !sample(0).Time.Equal(t)) {
+		return 0, fmt.Errorf("%w: %v out of the samples' time range", ErrRange, t)
+	}
+	after := sample(k)
+	if after.Time.Equal(t) {
+		return after.Value, err
+	}
+	before := sample(k - 1)
+	ratio := float64(t.Sub(before.Time)) / float64(after.Time.Sub(before.Time))
+	return before.Value + ratio*(after.Value-before.Value), err
+}
+
+//Gaps returns the periods longer than 'maxInterval' where no Sample arrived, from the oldest to the newest,
+//telling "the metric was zero" apart from "we were not receiving data".
+//
+// It includes the period from the newest sample to now (see WithClock), if it is too long already.
+// Other values are ignored.
+func (b *Ring) Gaps(maxInterval time.Duration) []TimeRange {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	var gaps []TimeRange
+	var last time.Time
+	for i := b.size - 1; i >= 0; i-- {
+		s, ok := b.buf[b.index(i)].(Sample)
+		if !ok {
+			continue
+		}
+		if !last.IsZero() && s.Time.Sub(last) > maxInterval {
+			gaps = append(gaps, TimeRange{last, s.Time})
+		}
+		last = s.Time
+	}
+	if now := b.now(); !last.IsZero() && now.Sub(last) > maxInterval {
+		gaps = append(gaps, TimeRange{last, now})
+	}
+	return gaps
+}
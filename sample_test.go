@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAt(t *testing.T) {
+	b := New(3)
+	if _, err := b.At(time.Now()); err != ErrEmpty {
+		t.Fatalf("At should fail on an empty ring, got %v", err)
+	}
+	t0 := time.Unix(100, 0)
+	b.Add(Sample{t0, 1}, Sample{t0.Add(10 * time.Second), 2}, Sample{t0.Add(20 * time.Second), 4})
+	for _, c := range []struct {
+		t    time.Time
+		want float64
+	}{
+		{t0, 1},
+		{t0.Add(5 * time.Second), 1.5},
+		{t0.Add(10 * time.Second), 2},
+		{t0.Add(15 * time.Second), 3},
+		{t0.Add(20 * time.Second), 4},
+	} {
+		if v, err := b.At(c.t); err != nil || v != c.want {
+			t.Fatalf("At(%v) should return %v, got %v, %v", c.t, c.want, v, err)
+		}
+	}
+	if _, err := b.At(t0.Add(-time.Second)); !errors.Is(err, ErrRange) {
+		t.Fatalf("At should fail before the oldest sample, got %v", err)
+	}
+	if _, err := b.At(t0.Add(21 * time.Second)); !errors.Is(err, ErrRange) {
+		t.Fatalf("At should fail after the newest sample, got %v", err)
+	}
+	b.Push(3.0)
+	if _, err := b.At(t0.Add(30 * time.Second)); !errors.Is(err, ErrNotNumeric) {
+		t.Fatalf("At should fail on values that are not samples, got %v", err)
+	}
+}
+
+func TestGaps(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	c := NewManualClock(t0.Add(30 * time.Second))
+	b := New(5, WithClock(c))
+	if b.Gaps(time.Second) != nil {
+		t.Fatalf("An empty ring has no gaps")
+	}
+	b.Add(Sample{t0, 0}, Sample{t0.Add(time.Second), 0}, "x", Sample{t0.Add(10 * time.Second), 0}, Sample{t0.Add(11 * time.Second), 0})
+	gaps := b.Gaps(5 * time.Second)
+	if len(gaps) != 2 || gaps[0].From != t0.Add(time.Second) || gaps[0].Duration() != 9*time.Second || gaps[1].To != c.Now() {
+		t.Fatalf("Invalid gaps %v", gaps)
+	}
+	c.Set(t0.Add(12 * time.Second))
+	if gaps := b.Gaps(5 * time.Second); len(gaps) != 1 {
+		t.Fatalf("Invalid gaps %v", gaps)
+	}
+}
@@ -0,0 +1,93 @@
+package ringbuffer
+
+import "strings"
+
+//Scrollback is a terminal scrollback buffer: it keeps the last rows of text, soft-wrapping long lines.
+type Scrollback struct {
+	ring  *Ring
+	width int
+}
+
+//row is a displayed row of a Scrollback.
+type row struct {
+	text string
+	cont bool // the row continues the previous one (soft-wrapped)
+}
+
+//NewScrollback creates a scrollback keeping the last 'capacity' rows.
+//
+// Lines longer than 'width' runes are soft-wrapped on several rows, 0 disables wrapping.
+func NewScrollback(capacity, width int) *Scrollback {
+	return &Scrollback{ring: New(capacity), width: width}
+}
+
+//AddLine appends 'line' at the bottom, discarding the oldest rows if needed.
+func (s *Scrollback) AddLine(line string) {
+	rows := []row{{text: line}}
+	if s.width > 0 {
+		rows = rows[:0]
+		runes := []rune(line)
+		for len(runes) > s.width {
+			rows = append(rows, row{text: string(runes[:s.width]), cont: len(rows) > 0})
+			runes = runes[s.width:]
+		}
+		rows = append(rows, row{text: string(runes), cont: len(rows) > 0})
+	}
+	s.ring.lock.Lock()
+	defer s.ring.unlock()
+	for _, r := range rows {
+		s.ring.put(r)
+	}
+}
+
+//Rows returns the number of rows.
+func (s *Scrollback) Rows() int {
+	return s.ring.Size()
+}
+
+//View returns at most 'rows' rows, from the top to the bottom, the last one being 'offset' rows above the bottom.
+func (s *Scrollback) View(offset, rows int) []string {
+	s.ring.lock.RLock()
+	defer s.ring.lock.RUnlock()
+	if offset < 0 {
+		offset = 0
+	}
+	if offset+rows > s.ring.size {
+		rows = s.ring.size - offset
+	}
+	if rows <= 0 {
+		return nil
+	}
+	view := make([]string, rows)
+	for i := range view {
+		view[i] = s.ring.buf[s.ring.index(offset+rows-1-i)].(row).text
+	}
+	return view
+}
+
+//Search returns the offsets (see View) of the lines containing 'pattern', from the bottom to the top.
+//
+// Soft-wrapped lines are searched as a whole, and reported at the offset of their first row.
+func (s *Scrollback) Search(pattern string) []int {
+	s.ring.lock.RLock()
+	defer s.ring.lock.RUnlock()
+	var offsets []int
+	var line []string // reversed rows of the current line
+	for i := 0; i < s.ring.size; i++ {
+		r := s.ring.buf[s.ring.index(i)].(row)
+		line = append(line, r.text)
+		if r.cont && i < s.ring.size-1 {
+			continue
+		}
+		// r is the first row of the line (or the oldest row left)
+		var text strings.Builder
+		for j := len(line) - 1; j >= 0; j-- {
+			text.WriteString(line[j])
+		}
+		if strings.Contains(text.String(), pattern) {
+			offsets = append(offsets, i)
+		}
+		line = line[:0]
+	}
+	return offsets
+}
@@ -0,0 +1,28 @@
+package ringbuffer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScrollback(t *testing.T) {
+	s := NewScrollback(4, 5)
+	s.AddLine("$ ls")
+	s.AddLine("hello world")
+	s.AddLine("$ pwd")
+	if s.Rows() != 4 {
+		t.Fatalf("Invalid rows %v, expecting %v", s.Rows(), 4)
+	}
+	if view := strings.Join(s.View(0, 10), "|"); view != "hello| worl|d|$ pwd" {
+		t.Fatalf("Invalid view %q", view)
+	}
+	if view := strings.Join(s.View(1, 2), "|"); view != " worl|d" {
+		t.Fatalf("Invalid view %q", view)
+	}
+	if offsets := s.Search("o w"); len(offsets) != 1 || offsets[0] != 3 {
+		t.Fatalf("Invalid search %v", offsets)
+	}
+	if offsets := s.Search("$"); len(offsets) != 1 || offsets[0] != 0 {
+		t.Fatalf("Invalid search %v", offsets)
+	}
+}
@@ -0,0 +1,238 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//segmentExt is the extension of segment files, named after the sequence number of their first record.
+const segmentExt = ".seg"
+
+//SegmentRing is a durable ring of byte records, stored in a directory of fixed-size segment files.
+//
+// Records are appended to the newest segment, and a new segment is started when it is full.
+// When the segments exceed the budget, the oldest one is deleted (like log rotation):
+// it drops the oldest records, far beyond RAM capacity.
+//
+// Records are written as their length (uvarint) followed by their bytes.
+type SegmentRing struct {
+	lock        sync.Mutex
+	dir         string
+	segmentSize int64
+	budget      int64
+	segments    []segmentFile // from the oldest to the newest
+	current     *os.File      // the newest segment, open for appending
+	seq         uint64
+}
+
+//segmentFile is a segment on disk.
+type segmentFile struct {
+	first uint64 // sequence number of its first record
+	size  int64
+}
+
+//OpenSegments opens the segment ring stored in 'dir', creating it if needed.
+//
+// Segments hold up to 'segmentSize' bytes (a single bigger record gets a segment of its own),
+// and the ring up to 'budget' bytes: whole segments are deleted to stay within, except the newest one.
+func OpenSegments(dir string, segmentSize, budget int64) (*SegmentRing, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	r := &SegmentRing{dir: dir, segmentSize: segmentSize, budget: budget}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		first, err := strconv.ParseUint(strings.TrimSuffix(e.Name(), segmentExt), 10, 64)
+		if e.IsDir() || !strings.HasSuffix(e.Name(), segmentExt) || err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		r.segments = append(r.segments, segmentFile{first: first, size: info.Size()})
+	}
+	sort.Slice(r.segments, func(i, j int) bool { return r.segments[i].first < r.segments[j].first })
+	if len(r.segments) == 0 {
+		return r, nil
+	}
+	last := &r.segments[len(r.segments)-1]
+	n, size, err := r.scan(last.first)
+	if err != nil {
+		return nil, err
+	}
+	if size < last.size { // drop a record partially written by a crash
+		if err := os.Truncate(r.path(last.first), size); err != nil {
+			return nil, err
+		}
+		last.size = size
+	}
+	r.seq = last.first + uint64(n) - 1
+	if r.current, err = os.OpenFile(r.path(last.first), os.O_WRONLY|os.O_APPEND, 0); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+//path returns the path of the segment starting at 'first'.
+func (r *SegmentRing) path(first uint64) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%020d%s", first, segmentExt))
+}
+
+//Push appends 'p' to the ring, deleting the oldest segments if the budget is exceeded.
+func (r *SegmentRing) Push(p []byte) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	record := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(p)), uint64(len(p)))
+	record = append(record, p...)
+
+	if r.current == nil || r.segments[len(r.segments)-1].size+int64(len(record)) > r.segmentSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := r.current.Write(record); err != nil {
+		return err
+	}
+	r.seq++
+	r.segments[len(r.segments)-1].size += int64(len(record))
+
+	total := int64(0)
+	for _, s := range r.segments {
+		total += s.size
+	}
+	for len(r.segments) > 1 && total > r.budget {
+		if err := os.Remove(r.path(r.segments[0].first)); err != nil {
+			return err
+		}
+		total -= r.segments[0].size
+		r.segments = r.segments[1:]
+	}
+	return nil
+}
+
+//rotate starts a new segment, unless the current one is empty.
+func (r *SegmentRing) rotate() error {
+	if r.current != nil {
+		if r.segments[len(r.segments)-1].size == 0 {
+			return nil
+		}
+		if err := r.current.Close(); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(r.path(r.seq+1), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	r.current = f
+	r.segments = append(r.segments, segmentFile{first: r.seq + 1})
+	return nil
+}
+
+//Values reads all the records, from the oldest to the newest.
+func (r *SegmentRing) Values() ([][]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var values [][]byte
+	for _, s := range r.segments {
+		err := r.read(s.first, false, func(p []byte) { values = append(values, p) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+//Sequence returns the sequence number of the newest record (see Ring.Sequence).
+func (r *SegmentRing) Sequence() uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.seq
+}
+
+//Sync commits the newest segment to stable storage.
+func (r *SegmentRing) Sync() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Sync()
+}
+
+//Close closes the newest segment.
+func (r *SegmentRing) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+//scan counts the complete records of the newest segment, and their size, ignoring a record torn by a crash.
+func (r *SegmentRing) scan(first uint64) (n int, size int64, err error) {
+	err = r.read(first, true, func(p []byte) {
+		n++
+		size += int64(len(binary.AppendUvarint(nil, uint64(len(p))))) + int64(len(p))
+	})
+	return n, size, err
+}
+
+//read calls 'f' for each record of a segment.
+//
+// A last record longer than the bytes left is ignored if 'torn' (written partially by a crash),
+// and fails with ErrSnapshot otherwise.
+func (r *SegmentRing) read(first uint64, torn bool, f func(p []byte)) error {
+	file, err := os.Open(r.path(first))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	left := info.Size()
+	br := bufio.NewReader(file)
+	for left > 0 {
+		n, err := binary.ReadUvarint(br)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: segment %d: %v", ErrSnapshot, first, err)
+		}
+		left -= int64(len(binary.AppendUvarint(nil, n)))
+		if n > uint64(left) {
+			if torn {
+				return nil
+			}
+			return fmt.Errorf("%w: segment %d: record of %d bytes, %d left", ErrSnapshot, first, n, left)
+		}
+		p := make([]byte, n)
+		if _, err := io.ReadFull(br, p); err != nil {
+			return err
+		}
+		left -= int64(n)
+		f(p)
+	}
+	if left > 0 && !torn {
+		return fmt.Errorf("%w: segment %d: truncated record length", ErrSnapshot, first)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSegments(t *testing.T) {
+	dir := t.TempDir()
+	r, err := OpenSegments(dir, 8, 16) // records of 4 bytes: 2 per segment, 4 records at most
+	if err != nil {
+		t.Fatalf("OpenSegments failed: %v", err)
+	}
+	for i := 0; i < 9; i++ {
+		if err := r.Push([]byte(fmt.Sprintf("v%02d", i))); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+	values, _ := r.Values()
+	if fmt.Sprintf("%s", values) != "[v06 v07 v08]" {
+		t.Fatalf("Oldest segments should be deleted, got %s", values)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("Invalid segments %v", entries)
+	}
+	r.Close()
+
+	// a crash while writing
+	f, _ := os.OpenFile(filepath.Join(dir, entries[1].Name()), os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{3, 'x'})
+	f.Close()
+	r, err = OpenSegments(dir, 8, 16)
+	if err != nil {
+		t.Fatalf("OpenSegments failed: %v", err)
+	}
+	defer r.Close()
+	if r.Sequence() != 9 {
+		t.Fatalf("Invalid sequence %v", r.Sequence())
+	}
+	r.Push([]byte("v09"))
+	values, _ = r.Values()
+	if fmt.Sprintf("%s", values) != "[v06 v07 v08 v09]" {
+		t.Fatalf("Reopened ring should append after the last complete record, got %s", values)
+	}
+}
+
+func TestSegmentsCorrupted(t *testing.T) {
+	dir := t.TempDir()
+	r, _ := OpenSegments(dir, 8, 64)
+	for i := 0; i < 4; i++ {
+		r.Push([]byte(fmt.Sprintf("v%02d", i)))
+	}
+	r.Close()
+	entries, _ := os.ReadDir(dir)
+
+	// a huge length in an old segment
+	os.WriteFile(filepath.Join(dir, entries[0].Name()), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 'x'}, 0o644)
+	r, err := OpenSegments(dir, 8, 64)
+	if err != nil {
+		t.Fatalf("OpenSegments failed: %v", err)
+	}
+	if _, err := r.Values(); !errors.Is(err, ErrSnapshot) {
+		t.Fatalf("A corrupted segment should fail with ErrSnapshot, got %v", err)
+	}
+
+	// a huge length in the newest segment is a torn record
+	os.WriteFile(filepath.Join(dir, entries[1].Name()), []byte{3, 'v', '0', '2', 0xff, 0xff, 0xff, 0xff, 0x0f}, 0o644)
+	r.Close()
+	r, err = OpenSegments(dir, 8, 64)
+	if err != nil || r.Sequence() != 3 {
+		t.Fatalf("The torn record should be cut off, got sequence %v, %v", r.Sequence(), err)
+	}
+	r.Close()
+}
@@ -0,0 +1,63 @@
+package ringbuffer
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+)
+
+//Select removes the oldest value of the first ring having one, waiting until any of 'rings' does.
+//
+// It returns the index of the ring in 'rings' and the value, or ctx.Err() if the context is done first.
+// Rings are scanned from a rotating position, so that a busy ring does not starve the others.
+//
+// Select parks while all the rings use the ParkWait strategy (the default), otherwise it polls them.
+func Select(ctx context.Context, rings ...*Ring) (idx int, v interface{}, err error) {
+	start := 0
+	cases := make([]reflect.SelectCase, 0, len(rings)+1)
+	for {
+		if idx, v, ok := selectPop(rings, start); ok {
+			return idx, v, nil
+		}
+		start++
+
+		cases = append(cases[:0], reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+		for _, b := range rings {
+			park, ok := b.waiter.(*parkWait)
+			if !ok {
+				cases = cases[:0]
+				break
+			}
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(park.channel())})
+		}
+		if len(cases) == 0 { // some ring does not park: poll
+			if err := ctx.Err(); err != nil {
+				return -1, nil, err
+			}
+			runtime.Gosched()
+			continue
+		}
+		// checked after registering, so that no signal is missed
+		if idx, v, ok := selectPop(rings, start); ok {
+			return idx, v, nil
+		}
+		if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+			return -1, nil, ctx.Err()
+		}
+	}
+}
+
+//selectPop pops the oldest value of the first non empty ring, starting at 'start'.
+func selectPop(rings []*Ring, start int) (idx int, v interface{}, ok bool) {
+	for i := range rings {
+		idx = (start + i) % len(rings)
+		b := rings[idx]
+		b.lock.Lock()
+		v, ok = b.pop()
+		b.unlock()
+		if ok {
+			return idx, v, true
+		}
+	}
+	return -1, nil, false
+}
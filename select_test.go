@@ -0,0 +1,38 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSelect(t *testing.T) {
+	a, b := New(2), New(2)
+	b.Add("b")
+	if idx, v, err := Select(context.Background(), a, b); err != nil || idx != 1 || v != "b" {
+		t.Fatalf("Select should return %v, %v, got %v, %v, %v", 1, "b", idx, v, err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		a.Add("a")
+	}()
+	if idx, v, err := Select(context.Background(), a, b); err != nil || idx != 0 || v != "a" {
+		t.Fatalf("Select should return %v, %v, got %v, %v, %v", 0, "a", idx, v, err)
+	}
+
+	c := New(2, WithWaitStrategy(YieldWait(10)))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.Add("c")
+	}()
+	if idx, v, err := Select(context.Background(), a, c); err != nil || idx != 1 || v != "c" {
+		t.Fatalf("Select should return %v, %v, got %v, %v, %v", 1, "c", idx, v, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := Select(ctx, a, b); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Select should time out, got %v", err)
+	}
+}
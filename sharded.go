@@ -0,0 +1,87 @@
+package ringbuffer
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+//ShardedRing splits its capacity across several rings (shards), each with its own lock.
+//
+// Producers are assigned a shard by key, so that high producer counts do not contend on a single mutex.
+// Readers get a merged view, ordered by insertion.
+type ShardedRing struct {
+	seq    atomic.Uint64
+	shards []*Ring
+}
+
+//sequenced is a value stamped with its insertion order.
+type sequenced struct {
+	seq   uint64
+	value interface{}
+}
+
+//NewSharded creates a new, empty sharded ring, splitting 'capacity' across 'shards' rings.
+//
+// There are at most 'capacity' shards, so that every shard holds at least a value.
+func NewSharded(shards, capacity int) *ShardedRing {
+	shards = min(shards, capacity)
+	if shards < 1 {
+		shards = 1
+	}
+	s := &ShardedRing{shards: make([]*Ring, shards)}
+	for i := range s.shards {
+		// spread the remainder over the first shards
+		c := capacity / shards
+		if i < capacity%shards {
+			c++
+		}
+		s.shards[i] = New(c)
+	}
+	return s
+}
+
+//Push adds 'values' to the shard selected by 'key', discarding the shard's oldest values if it is full.
+//
+// Each producer should use its own key.
+func (s *ShardedRing) Push(key int, values ...interface{}) {
+	b := s.shards[uint(key)%uint(len(s.shards))] // any key, even math.MinInt
+	b.lock.Lock()
+	defer b.unlock()
+	for _, v := range values {
+		b.put(sequenced{seq: s.seq.Add(1), value: v})
+	}
+}
+
+//Values returns a copy of all the shards' values, from the oldest to the newest.
+func (s *ShardedRing) Values() []interface{} {
+	var all []interface{}
+	for _, b := range s.shards {
+		all = append(all, b.Values()...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].(sequenced).seq < all[j].(sequenced).seq })
+	for i, v := range all {
+		all[i] = v.(sequenced).value
+	}
+	return all
+}
+
+//Shards returns the number of shards.
+func (s *ShardedRing) Shards() int {
+	return len(s.shards)
+}
+
+//Size returns the total size of the shards.
+func (s *ShardedRing) Size() (n int) {
+	for _, b := range s.shards {
+		n += b.Size()
+	}
+	return n
+}
+
+//Capacity returns the total capacity of the shards.
+func (s *ShardedRing) Capacity() (n int) {
+	for _, b := range s.shards {
+		n += b.Capacity()
+	}
+	return n
+}
@@ -0,0 +1,68 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSharded(t *testing.T) {
+	s := NewSharded(3, 10)
+	if s.Capacity() != 10 {
+		t.Fatalf("Invalid capacity %v, expecting %v", s.Capacity(), 10)
+	}
+	for i := 0; i < 20; i++ {
+		s.Push(i, i)
+	}
+	if s.Size() != 10 {
+		t.Fatalf("Invalid size %v, expecting %v", s.Size(), 10)
+	}
+	// shard 0 keeps 4 values (9, 12, 15, 18), shard 1 and 2 keep 3 values
+	want := []interface{}{9, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	values := s.Values()
+	if len(values) != len(want) {
+		t.Fatalf("Invalid values %v, expecting %v", values, want)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Fatalf("Invalid values %v, expecting %v", values, want)
+		}
+	}
+}
+
+func TestShardedSmallCapacity(t *testing.T) {
+	s := NewSharded(4, 2)
+	if len(s.shards) != 2 || s.Capacity() != 2 {
+		t.Fatalf("There should be at most 'capacity' shards, got %v shards of capacity %v", len(s.shards), s.Capacity())
+	}
+	for key := 0; key < 4; key++ {
+		s.Push(key, key)
+	}
+	if s.Size() != 2 {
+		t.Fatalf("No key should be routed to an empty shard, got size %v", s.Size())
+	}
+	s.Push(math.MinInt, "min")
+	s.Push(-1, "negative")
+	if fmt.Sprint(s.Values()) != "[min negative]" {
+		t.Fatalf("Negative keys should select a shard, got %v", s.Values())
+	}
+}
+
+func TestConcurrentSharded(t *testing.T) {
+	s := NewSharded(4, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Push(key, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if s.Size() != 100 {
+		t.Fatalf("Invalid size %v, expecting %v", s.Size(), 100)
+	}
+}
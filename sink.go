@@ -0,0 +1,99 @@
+package ringbuffer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//SnapshotSink receives the ring checkpoints taken by a Snapshotter, to store them anywhere (a bucket, a database, ...).
+type SnapshotSink interface {
+	//WriteSnapshot stores 'data', a ring file (see Ring.MarshalFile) whose newest value has the sequence number 'seq'.
+	WriteSnapshot(ctx context.Context, seq uint64, data []byte) error
+}
+
+//SnapshotSinkFunc adapts a function to a SnapshotSink.
+type SnapshotSinkFunc func(ctx context.Context, seq uint64, data []byte) error
+
+//WriteSnapshot calls f(ctx, seq, data).
+func (f SnapshotSinkFunc) WriteSnapshot(ctx context.Context, seq uint64, data []byte) error {
+	return f(ctx, seq, data)
+}
+
+//Snapshotter periodically writes checkpoints of a ring to a SnapshotSink.
+type Snapshotter struct {
+	ring      *Ring
+	sink      SnapshotSink
+	encode    func(v interface{}) ([]byte, error)
+	interval  time.Duration
+	evictions uint64
+
+	lock    sync.Mutex // guards the last snapshot's state, and serializes the snapshots
+	last    uint64     // sequence number of the last snapshot
+	size    int        // size of the ring at the last snapshot
+	dropped uint64     // dropped values at the last snapshot
+}
+
+//NewSnapshotter creates a snapshotter taking a checkpoint of 'b' every 'interval',
+// or as soon as 'evictions' values have been pushed out of the ring, whichever comes first.
+//
+// A zero 'interval' or 'evictions' disables the corresponding trigger. Values are encoded by 'encode' (see MarshalSnapshot).
+// The interval is measured by the ring's clock (see WithClock).
+func NewSnapshotter(b *Ring, sink SnapshotSink, encode func(v interface{}) ([]byte, error), interval time.Duration, evictions uint64) *Snapshotter {
+	return &Snapshotter{ring: b, sink: sink, encode: encode, interval: interval, evictions: evictions, size: b.Size(), dropped: b.Dropped()}
+}
+
+//Run takes checkpoints until 'ctx' is done, then it returns ctx.Err().
+//
+// Checkpoints of an unchanged ring are skipped. Run stops and returns the error of a failed checkpoint.
+func (s *Snapshotter) Run(ctx context.Context) error {
+	b := s.ring
+	for {
+		evicted := func() bool { return s.evictions > 0 && b.Dropped()-s.lastDropped() >= s.evictions }
+		wait, cancel := ctx, context.CancelFunc(func() {})
+		if s.interval > 0 {
+			wait, cancel = b.withTimeout(ctx, s.interval)
+		}
+		for !evicted() && b.waiter.Wait(wait, evicted) == nil {
+		}
+		cancel()
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.Snapshot(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+//lastDropped returns the number of dropped values at the last snapshot.
+func (s *Snapshotter) lastDropped() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.dropped
+}
+
+//Snapshot takes a checkpoint now, unless the ring has not changed since the last one.
+//
+// It is safe to call concurrently with Run.
+func (s *Snapshotter) Snapshot(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	b := s.ring
+	b.lock.RLock()
+	seq, size, dropped := b.seq, b.size, b.stats.dropped.Load()
+	if seq == s.last && size == s.size && dropped == s.dropped && seq != 0 { // removals do not move the sequence
+		b.lock.RUnlock()
+		return nil
+	}
+	data, err := b.marshalFile(s.encode) // under the same lock, so that 'seq' describes 'data'
+	b.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+	if err := s.sink.WriteSnapshot(ctx, seq, data); err != nil {
+		return err
+	}
+	s.last, s.size, s.dropped = seq, size, dropped
+	return nil
+}
@@ -0,0 +1,133 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSnapshotter(t *testing.T) {
+	b := New(2)
+	snapshots := make(chan uint64, 10)
+	sink := SnapshotSinkFunc(func(ctx context.Context, seq uint64, data []byte) error {
+		c, err := UnmarshalFile(data, decodeString)
+		if err != nil || c.Sequence() != seq {
+			return fmt.Errorf("invalid snapshot %v, %v", c, err)
+		}
+		snapshots <- seq
+		return nil
+	})
+	s := NewSnapshotter(b, sink, encodeString, 0, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	b.Push("a", "b", "c") // pushed into an empty ring: 3 dropped values
+	select {
+	case seq := <-snapshots:
+		if seq != 3 {
+			t.Fatalf("Invalid snapshot sequence %v", seq)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Evictions should trigger a snapshot")
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run should return the context error, got %v", err)
+	}
+
+	s = NewSnapshotter(b, sink, encodeString, 10*time.Millisecond, 0)
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	b.Add("d")
+	s.Run(ctx)
+	if len(snapshots) != 1 {
+		t.Fatalf("Unchanged rings should not be snapshot again, got %v snapshots", len(snapshots))
+	}
+}
+
+func TestSnapshotterRemove(t *testing.T) {
+	b := New(3)
+	var sizes []int
+	sink := SnapshotSinkFunc(func(ctx context.Context, seq uint64, data []byte) error {
+		c, err := UnmarshalFile(data, decodeString)
+		if err != nil {
+			return err
+		}
+		sizes = append(sizes, c.Size())
+		return nil
+	})
+	s := NewSnapshotter(b, sink, encodeString, 0, 0)
+	b.Add("a", "b")
+	s.Snapshot(context.Background())
+	s.Snapshot(context.Background())
+	b.Remove(1)
+	s.Snapshot(context.Background())
+	b.Reset(3)
+	s.Snapshot(context.Background())
+	if fmt.Sprint(sizes) != "[2 1 0]" {
+		t.Fatalf("Removals should be snapshot, got sizes %v", sizes)
+	}
+}
+
+func TestSnapshotterConcurrent(t *testing.T) {
+	b := New(3)
+	b.Add("a", "b")
+	s := NewSnapshotter(b, SnapshotSinkFunc(func(ctx context.Context, seq uint64, data []byte) error {
+		if c, err := UnmarshalFile(data, decodeString); err != nil || c.Sequence() != seq {
+			return fmt.Errorf("the sequence %v should describe the snapshot %v, %v", seq, c, err)
+		}
+		return nil
+	}), encodeString, time.Millisecond, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			b.Push("a")
+			if err := s.Snapshot(ctx); err != nil && ctx.Err() == nil {
+				t.Errorf("Snapshot failed: %v", err)
+			}
+		}
+	}()
+	if err := s.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run should return the context error, got %v", err)
+	}
+	<-done
+	if err := s.Snapshot(context.Background()); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+}
+
+func TestSnapshotterClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	b := New(3, WithClock(clock))
+	snapshots := make(chan uint64, 10)
+	s := NewSnapshotter(b, SnapshotSinkFunc(func(ctx context.Context, seq uint64, data []byte) error {
+		snapshots <- seq
+		return nil
+	}), encodeString, time.Millisecond, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+	b.Add("a")
+	select {
+	case seq := <-snapshots:
+		t.Fatalf("The interval should be measured by the ring's clock, got snapshot %v", seq)
+	case <-time.After(20 * time.Millisecond):
+	}
+	for {
+		clock.Advance(time.Millisecond)
+		select {
+		case seq := <-snapshots:
+			if seq != 1 {
+				t.Fatalf("Invalid snapshot sequence %v", seq)
+			}
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package ringbuffer
+
+//Slices returns the ring's storage holding its values, as one or two contiguous regions in logical order:
+// 'older' holds the oldest values, 'newer' the following ones (it is empty unless the values wrap around).
+//
+// No value is copied: the slices alias the ring's storage. They must not be modified,
+// and they are only valid until the ring is modified, it is up to the caller to prevent concurrent writes.
+func (b *Ring) Slices() (older, newer []interface{}) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.slices()
+}
+
+//Slices returns the ring's storage holding its values (see Ring.Slices).
+func (b *Unlocked) Slices() (older, newer []interface{}) { return b.slices() }
+
+//slices returns the one or two regions holding the values, from the oldest to the newest.
+func (b *ring) slices() (older, newer []interface{}) {
+	if b.size == 0 {
+		return nil, nil
+	}
+	older, newer = b.span(b.index(-1), b.size)
+	return older[:len(older):len(older)], newer[:len(newer):len(newer)]
+}
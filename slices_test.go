@@ -0,0 +1,31 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSlices(t *testing.T) {
+	b := New(4)
+	if older, newer := b.Slices(); older != nil || newer != nil {
+		t.Fatalf("An empty ring has no slice")
+	}
+	b.Add(1, 2, 3)
+	if older, newer := b.Slices(); fmt.Sprint(older, newer) != "[1 2 3] []" {
+		t.Fatalf("Invalid slices %v %v", older, newer)
+	}
+	b.Push(4, 5)
+	older, newer := b.Slices()
+	if fmt.Sprint(older, newer) != "[3 4] [5]" {
+		t.Fatalf("Invalid slices %v %v", older, newer)
+	}
+	if cap(older) != len(older) {
+		t.Fatalf("Slices should not be appendable into the ring's storage")
+	}
+
+	u := NewUnlocked(2)
+	u.Add(1, 2)
+	if older, newer := u.Slices(); fmt.Sprint(older, newer) != "[1 2] []" {
+		t.Fatalf("Invalid slices %v %v", older, newer)
+	}
+}
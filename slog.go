@@ -0,0 +1,117 @@
+package ringbuffer
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+//LogHandler is a slog.Handler keeping the last records in a ring.
+//
+// It is meant to include "recent logs" in crash reports or health endpoints,
+// typically along with the actual handler (see slog.Handler documentation to fan out records).
+type LogHandler struct {
+	ring   *Ring
+	level  slog.Leveler
+	attrs  []slog.Attr // already nested in their groups
+	groups []string    // opened groups, for the records' attributes
+}
+
+//NewLogHandler creates a handler keeping the last 'capacity' records.
+//
+// Only opts.Level is used, opts can be nil.
+func NewLogHandler(capacity int, opts *slog.HandlerOptions) *LogHandler {
+	h := &LogHandler{ring: New(capacity), level: slog.LevelInfo}
+	if opts != nil && opts.Level != nil {
+		h.level = opts.Level
+	}
+	return h
+}
+
+//Enabled reports whether the handler keeps records at 'level'.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+//Handle keeps a copy of 'r', discarding the oldest record if the ring is full.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	nr.AddAttrs(h.attrs...)
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	nr.AddAttrs(nest(h.groups, attrs)...)
+
+	h.ring.lock.Lock()
+	defer h.ring.unlock()
+	h.ring.put(nr)
+	return nil
+}
+
+//WithAttrs returns a handler sharing the same ring, adding 'attrs' to every record.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = append(append([]slog.Attr(nil), h.attrs...), nest(h.groups, attrs)...)
+	return &c
+}
+
+//WithGroup returns a handler sharing the same ring, qualifying the next attributes with 'name'.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.groups = append(append([]string(nil), h.groups...), name)
+	return &c
+}
+
+//Records returns the kept records, from the oldest to the newest.
+func (h *LogHandler) Records() []slog.Record {
+	values := h.ring.Values()
+	records := make([]slog.Record, len(values))
+	for i, v := range values {
+		records[i] = v.(slog.Record)
+	}
+	return records
+}
+
+//WriteTo writes the kept records to 'w', from the oldest to the newest, using slog's text format.
+func (h *LogHandler) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countWriter{w: w}
+	text := slog.NewTextHandler(cw, &slog.HandlerOptions{Level: slog.Level(-1 << 31)})
+	for _, r := range h.Records() {
+		if err = text.Handle(context.Background(), r); err != nil {
+			break
+		}
+	}
+	return cw.n, err
+}
+
+//nest returns 'attrs' nested in 'groups'.
+func nest(groups []string, attrs []slog.Attr) []slog.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+	for i := len(groups) - 1; i >= 0; i-- {
+		args := make([]interface{}, len(attrs))
+		for j, a := range attrs {
+			args[j] = a
+		}
+		attrs = []slog.Attr{slog.Group(groups[i], args...)}
+	}
+	return attrs
+}
+
+//countWriter counts the bytes written to 'w'.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
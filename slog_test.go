@@ -0,0 +1,38 @@
+package ringbuffer
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogHandler(t *testing.T) {
+	h := NewLogHandler(2, nil)
+	logger := slog.New(h).With("app", "test")
+	logger.Debug("ignored")
+	logger.Info("first")
+	logger.WithGroup("req").Info("second", "id", 1)
+	logger.Warn("third")
+
+	records := h.Records()
+	if len(records) != 2 || records[0].Message != "second" || records[1].Message != "third" {
+		t.Fatalf("Invalid records %v", records)
+	}
+
+	var out strings.Builder
+	n, err := h.WriteTo(&out)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if int(n) != out.Len() {
+		t.Fatalf("Invalid count %v, expecting %v", n, out.Len())
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], `level=INFO msg=second app=test req.id=1`) {
+		t.Fatalf("Invalid output %q", out.String())
+	}
+	if !strings.HasPrefix(lines[0], "time="+records[0].Time.Format(time.RFC3339)[:10]) {
+		t.Fatalf("Invalid output %q", out.String())
+	}
+}
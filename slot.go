@@ -0,0 +1,70 @@
+package ringbuffer
+
+//Slot is a position reserved in a ring, to be filled later (see ReserveSlot).
+type Slot struct {
+	ring   *Ring
+	ticket uint64
+}
+
+//canceled marks a canceled slot.
+type canceled struct{}
+
+//ReserveSlot reserves room for a value to be published later, or fails with ErrFull.
+//
+// Producers reserve slots cheaply, and then build their values concurrently, without holding the ring's lock.
+// Readers only see published values, in the order of the reservations:
+// a slot published early waits for the previous ones to be published (or canceled).
+//
+// Reserved room is not available to Add, but Push and Remove are not constrained.
+func (b *Ring) ReserveSlot() (Slot, error) {
+	b.lock.Lock()
+	defer b.unlock()
+	if b.size+b.reserved >= b.capacity {
+		return Slot{}, b.overflow([]interface{}{nil})
+	}
+	b.reserved++
+	if b.pending == nil {
+		b.pending = make(map[uint64]interface{})
+	}
+	slot := Slot{ring: b, ticket: b.tickets}
+	b.tickets++
+	return slot, nil
+}
+
+//Publish fills the slot with 'v'.
+//
+// It panics with ErrSlot if the slot has already been published or canceled.
+func (s Slot) Publish(v interface{}) {
+	s.ring.lock.Lock()
+	defer s.ring.unlock()
+	s.ring.commit(s.ticket, v)
+}
+
+//Cancel releases the slot without filling it.
+//
+// It panics with ErrSlot if the slot has already been published or canceled.
+func (s Slot) Cancel() {
+	s.ring.lock.Lock()
+	defer s.ring.unlock()
+	s.ring.commit(s.ticket, canceled{})
+}
+
+//commit records the value of the slot 'ticket', and adds the values of all the consecutive published slots.
+func (b *ring) commit(ticket uint64, v interface{}) {
+	if _, done := b.pending[ticket]; done || ticket < b.committed || ticket >= b.tickets {
+		panic(ErrSlot)
+	}
+	b.pending[ticket] = v
+	for {
+		v, ok := b.pending[b.committed]
+		if !ok {
+			return
+		}
+		delete(b.pending, b.committed)
+		b.committed++
+		b.reserved--
+		if _, skip := v.(canceled); !skip {
+			b.put(v)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestReserveSlot(t *testing.T) {
+	b := New(3)
+	b.Add(1)
+	s2, _ := b.ReserveSlot()
+	s3, _ := b.ReserveSlot()
+	if _, err := b.ReserveSlot(); !errors.Is(err, ErrFull) {
+		t.Fatalf("ReserveSlot should fail on a full ring, got %v", err)
+	}
+	if err := b.Add(4); !errors.Is(err, ErrFull) {
+		t.Fatalf("Add should not use reserved room, got %v", err)
+	}
+	s3.Publish(3)
+	if fmt.Sprint(b.Values()) != "[1]" {
+		t.Fatalf("A slot should wait for the previous ones, got %v", b)
+	}
+	s2.Publish(2)
+	if fmt.Sprint(b.Values()) != "[1 2 3]" {
+		t.Fatalf("Published slots should be added in order, got %v", b)
+	}
+	assertPanics(t, ErrSlot, func() { s2.Publish(2) })
+
+	b.Remove(3)
+	s1, _ := b.ReserveSlot()
+	s2, _ = b.ReserveSlot()
+	s2.Publish(2)
+	s1.Cancel()
+	if fmt.Sprint(b.Values()) != "[2]" || b.Free() != 2 {
+		t.Fatalf("Canceled slots should be skipped, got %v", b)
+	}
+}
+
+func TestConcurrentSlots(t *testing.T) {
+	b := New(100)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				s, err := b.ReserveSlot()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				s.Publish(j)
+			}
+		}()
+	}
+	wg.Wait()
+	if b.Size() != 100 || b.Free() != 0 {
+		t.Fatalf("Invalid ring %v", b)
+	}
+}
@@ -0,0 +1,34 @@
+package ringbuffer
+
+import "time"
+
+//WithSnapshots makes readers use an immutable copy of the ring, published by writers.
+//
+// Get and Values then never lock the ring: readers never block writers and vice versa.
+// In exchange every modification copies the whole ring, so it is only suitable for read-heavy workloads.
+//
+// It has no effect on Unlocked rings.
+func WithSnapshots() Option {
+	return func(b *ring) {
+		b.snapshots = true
+	}
+}
+
+//snapshot is an immutable copy of a ring's values.
+type snapshot struct {
+	values []interface{} // from the oldest to the newest
+	taken  time.Time     // see WithRelaxedReads
+}
+
+//get returns the value at index 'i' (see Ring.Get).
+func (s *snapshot) get(i int) (interface{}, error) {
+	size := len(s.values)
+	if size == 0 {
+		return 0, ErrEmpty
+	}
+	i %= size
+	if i < 0 {
+		i += size
+	}
+	return s.values[size-1-i], nil
+}
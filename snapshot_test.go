@@ -0,0 +1,50 @@
+package ringbuffer
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshots(t *testing.T) {
+	x := New(5)
+	b := New(5, WithSnapshots())
+	if _, err := b.Get(0); err != ErrEmpty {
+		t.Fatalf("should have failed with ErrEmpty, got %v", err)
+	}
+	x.Add(1, 2, 3)
+	b.Add(1, 2, 3)
+	x.Push(4, 5, 6, 7)
+	b.Push(4, 5, 6, 7)
+	if !equals(b, x) {
+		t.Errorf("snapshot ring differs:\nreal%v\ngold%v\n", b.Values(), x.Values())
+	}
+	values := b.Values()
+	for i, v := range []interface{}{5, 6, 7} {
+		if values[i] != v {
+			t.Fatalf("Values()[%v] = %v, expecting %v", i, values[i], v)
+		}
+	}
+}
+
+func TestConcurrentSnapshots(t *testing.T) {
+	b := New(10, WithSnapshots())
+	b.Add(0, 0, 0)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 1000; j++ {
+			b.Push(j)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 1000; j++ {
+			if len(b.Values()) != 3 {
+				t.Errorf("Invalid size %v", len(b.Values()))
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
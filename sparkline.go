@@ -0,0 +1,59 @@
+package ringbuffer
+
+import (
+	"math"
+	"strings"
+)
+
+//sparks are the sparkline's levels, from the lowest to the highest.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+//Sparkline renders the ring's numeric values (see Sample), from the oldest to the newest, as Unicode block characters,
+//for terminal dashboards and log lines.
+//
+// The values are averaged down to at most 'width' characters (none if it is not positive), and scaled between their min and their max.
+// Other values are ignored, and a character without any numeric value is rendered as a space.
+func (b *Ring) Sparkline(width int) string {
+	b.lock.RLock()
+	values := b.values()
+	b.lock.RUnlock()
+	return sparkline(values, width)
+}
+
+//sparkline renders 'values' (see Ring.Sparkline).
+func sparkline(values []interface{}, width int) string {
+	if width > len(values) {
+		width = len(values)
+	}
+	if width <= 0 {
+		return ""
+	}
+	columns := make([]float64, width)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for c := range columns {
+		sum, n := 0., 0
+		for _, v := range values[c*len(values)/width : (c+1)*len(values)/width] {
+			if x, ok := number(v); ok {
+				sum += x
+				n++
+			}
+		}
+		columns[c] = math.NaN()
+		if n > 0 {
+			columns[c] = sum / float64(n)
+			lo, hi = min(lo, columns[c]), max(hi, columns[c])
+		}
+	}
+	var s strings.Builder
+	for _, x := range columns {
+		switch {
+		case math.IsNaN(x):
+			s.WriteRune(' ')
+		case hi == lo:
+			s.WriteRune(sparks[0])
+		default:
+			s.WriteRune(sparks[int((x-lo)/(hi-lo)*float64(len(sparks)-1)+0.5)])
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,25 @@
+package ringbuffer
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	b := New(8)
+	if s := b.Sparkline(10); s != "" {
+		t.Fatalf("An empty ring renders nothing, got %q", s)
+	}
+	b.Add(0, 1, 2, 3, 4, 5, 6, 7)
+	if s := b.Sparkline(10); s != "▁▂▃▄▅▆▇█" {
+		t.Fatalf("Invalid sparkline %q", s)
+	}
+	if s := b.Sparkline(4); s != "▁▃▆█" {
+		t.Fatalf("Invalid averaged sparkline %q", s)
+	}
+	if s := b.Sparkline(0) + b.Sparkline(-1); s != "" {
+		t.Fatalf("A non-positive width renders nothing, got %q", s)
+	}
+	b = New(4)
+	b.Add(1, "x", 1, 1)
+	if s := b.Sparkline(4); s != "▁ ▁▁" {
+		t.Fatalf("Invalid sparkline %q", s)
+	}
+}
@@ -0,0 +1,107 @@
+package ringbuffer
+
+//GetStamped returns the value at index 'i' (see Get), and its generation: the sequence number the value was added with.
+//
+// The generation can later be checked with StillValid, to detect that the value was pushed out of the ring meanwhile.
+// It follows the value when the ring is reordered (see Swap).
+func (b *Ring) GetStamped(i int) (v interface{}, gen uint64, err error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.getStamped(i)
+}
+
+//StillValid tells whether the value of generation 'gen' (see GetStamped) is still in the ring.
+func (b *Ring) StillValid(gen uint64) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.stillLive(gen)
+}
+
+//getStamped returns the value at index 'i' and its generation (see GetStamped).
+func (b *ring) getStamped(i int) (interface{}, uint64, error) {
+	v, err := b.get(i)
+	if err != nil {
+		return v, 0, err
+	}
+	return v, b.generation(b.position(i)), nil
+}
+
+//position returns the sequence number of the value currently at index 'i', that must be valid (see Get).
+func (b *ring) position(i int) uint64 {
+	i = b.logical(i) % b.size
+	if i < 0 {
+		i += b.size
+	}
+	return b.seq - uint64(i)
+}
+
+//stillValid tells whether the value of sequence number 'gen' is still in the ring.
+func (b *ring) stillValid(gen uint64) bool {
+	return gen <= b.seq && gen > b.seq-uint64(b.size)
+}
+
+//generation returns the generation of the value at the sequence number 'seq':
+// 'seq' itself, unless the value was moved by a reorder (see Swap).
+func (b *ring) generation(seq uint64) uint64 {
+	if gen, ok := b.generations[seq]; ok {
+		return gen
+	}
+	return seq
+}
+
+//resolve returns the current sequence number of the value of generation 'gen', and false if it has left the ring.
+func (b *ring) resolve(gen uint64) (uint64, bool) {
+	seq, moved := b.positions[gen]
+	if !moved {
+		if _, taken := b.generations[gen]; taken { // the value has moved, and left the ring since
+			return 0, false
+		}
+		seq = gen
+	}
+	return seq, b.stillValid(seq)
+}
+
+//stillLive tells whether the value of generation 'gen' is still in the ring.
+func (b *ring) stillLive(gen uint64) bool {
+	_, ok := b.resolve(gen)
+	return ok
+}
+
+//exchange exchanges the generations of the values at the sequence numbers 'si' and 'sj', when they are swapped.
+func (b *ring) exchange(si, sj uint64) {
+	gi, gj := b.generation(si), b.generation(sj)
+	b.setGeneration(si, gj)
+	b.setGeneration(sj, gi)
+	b.pruneGenerations()
+}
+
+//setGeneration records that the value at the sequence number 'seq' has the generation 'gen'.
+func (b *ring) setGeneration(seq, gen uint64) {
+	if seq == gen {
+		delete(b.generations, seq)
+		delete(b.positions, gen)
+		return
+	}
+	if b.generations == nil {
+		b.generations, b.positions = make(map[uint64]uint64), make(map[uint64]uint64)
+	}
+	b.generations[seq] = gen
+	b.positions[gen] = seq
+}
+
+//pruneGenerations forgets the generations of the values that have left the ring.
+//
+// The generations are scanned once they outnumber the ring's values twice, amortizing the cost.
+func (b *ring) pruneGenerations() {
+	if len(b.generations) <= 2*b.size {
+		return
+	}
+	for seq, gen := range b.generations {
+		if !b.stillValid(seq) {
+			delete(b.generations, seq)
+			if b.positions[gen] == seq {
+				delete(b.positions, gen)
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package ringbuffer
+
+import "testing"
+
+func TestGetStamped(t *testing.T) {
+	b := New(3)
+	if _, _, err := b.GetStamped(0); err != ErrEmpty {
+		t.Fatalf("GetStamped should fail on an empty ring, got %v", err)
+	}
+	b.Add(1, 2, 3)
+	v, gen, _ := b.GetStamped(-1)
+	if v != 1 || gen != 1 {
+		t.Fatalf("GetStamped(-1) should return %v, %v, got %v, %v", 1, 1, v, gen)
+	}
+	v, newest, _ := b.GetStamped(0)
+	if v != 3 || newest != 3 {
+		t.Fatalf("GetStamped(0) should return %v, %v, got %v, %v", 3, 3, v, newest)
+	}
+	if !b.StillValid(gen) {
+		t.Fatalf("Generation %v should be valid", gen)
+	}
+	b.Push(4)
+	if b.StillValid(gen) || !b.StillValid(newest) {
+		t.Fatalf("Generation %v should have been overwritten, not %v", gen, newest)
+	}
+	if b.StillValid(5) {
+		t.Fatalf("Future generations should not be valid")
+	}
+}
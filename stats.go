@@ -0,0 +1,50 @@
+package ringbuffer
+
+import "sync/atomic"
+
+//cacheLine is the (common) size of a CPU cache line.
+const cacheLine = 64
+
+//counters are the ring's statistics, they can be read without locking the ring.
+type counters struct {
+	size, capacity atomic.Int64
+	dropped        atomic.Uint64
+}
+
+//paddedCounters isolates counters on their own cache lines.
+type paddedCounters struct {
+	_ [cacheLine]byte
+	counters
+	_ [cacheLine]byte
+}
+
+//WithPadding isolates the ring's statistics (the counters behind Size, Capacity and Dropped) on their own cache lines.
+//
+// Readers polling them at high frequency then do not invalidate the cache line holding the lock and the head,
+// avoiding false sharing with writers. The head, the size and the buffer pointer themselves are not padded:
+// they are only accessed under the lock, by one goroutine at a time, so they share its cache line on purpose.
+func WithPadding() Option {
+	return func(b *ring) {
+		b.padded = true
+	}
+}
+
+//newCounters allocates the statistics, padded or not.
+func newCounters(padded bool) *counters {
+	if padded {
+		return &new(paddedCounters).counters
+	}
+	return new(counters)
+}
+
+//publish updates the statistics with the ring's current state.
+func (b *ring) publish() {
+	b.stats.size.Store(int64(b.size))
+	b.stats.capacity.Store(int64(b.capacity))
+	if b.occupancy != nil {
+		b.occupancy.Record(utilization(b.size, b.capacity))
+	}
+	if b.snapshots {
+		b.snapshot.Store(&snapshot{values: b.values()})
+	}
+}
@@ -0,0 +1,78 @@
+package ringbuffer
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDropped(t *testing.T) {
+	b := New(3)
+	b.Push(1) // nothing to replace
+	b.Add(1, 2)
+	b.Push(3, 4, 5)
+	if b.Dropped() != 4 {
+		t.Fatalf("Invalid dropped %v, expecting %v", b.Dropped(), 4)
+	}
+
+	b = New(3, WithBudget(4, func(v interface{}) int { return v.(int) }))
+	b.Push(1, 2, 3, 5)
+	if b.Dropped() != 3 || b.Size() != 1 {
+		t.Fatalf("Invalid dropped/size %v/%v, expecting %v/%v", b.Dropped(), b.Size(), 3, 1)
+	}
+}
+
+func TestConcurrentStats(t *testing.T) {
+	b := New(100)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				b.Add(j)
+				b.Remove(1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if s := b.Size(); s < 0 || s > b.Capacity() {
+					t.Errorf("Invalid size %v", s)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if b.Size() != 0 {
+		t.Fatalf("Invalid size %v, expecting %v", b.Size(), 0)
+	}
+}
+
+func benchmarkPolledPush(b *testing.B, options ...Option) {
+	r := New(1000, options...)
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	for i := 0; i < 3; i++ {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					r.Size()
+				}
+			}
+		}()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Push(i)
+	}
+}
+
+func BenchmarkPolledPush(b *testing.B)       { benchmarkPolledPush(b) }
+func BenchmarkPolledPushPadded(b *testing.B) { benchmarkPolledPush(b, WithPadding()) }
@@ -0,0 +1,97 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//stringEnds is the number of values shown at each end of a big ring by String.
+const stringEnds = 8
+
+//String returns the ring's size, capacity and values from the oldest to the newest.
+//
+// Big rings are truncated to their first and last values.
+func (b *Ring) String() string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.string()
+}
+
+//GoString returns the ring's full internal state.
+func (b *Ring) GoString() string {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return "&ringbuffer.Ring" + b.goString()
+}
+
+//string formats the ring (see Ring.String).
+func (b *ring) string() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "ring(%d/%d)[", b.size, b.capacity)
+	for i := b.size - 1; i >= 0; i-- { // from the oldest (size-1) to the newest (0)
+		if i < b.size-1 {
+			s.WriteByte(' ')
+		}
+		if b.size > 2*stringEnds && i == b.size-1-stringEnds {
+			s.WriteString("...")
+			i = stringEnds
+			continue
+		}
+		s.WriteString(b.format(b.buf[b.index(i)]))
+	}
+	s.WriteByte(']')
+	return s.String()
+}
+
+//SetFormatter sets the function formatting values in String and Dump.
+//
+// It makes rings of structs or byte slices readable, or redacts sensitive values. nil restores the default (fmt.Sprint).
+func (b *Ring) SetFormatter(format func(v interface{}) string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.formatter = format
+}
+
+//Dump writes the ring's values to 'w', one per line, from the oldest to the newest.
+func (b *Ring) Dump(w io.Writer) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.dump(w)
+}
+
+//format returns the value formatted by the formatter.
+func (b *ring) format(v interface{}) string {
+	if b.formatter == nil {
+		return fmt.Sprint(v)
+	}
+	return b.formatter(v)
+}
+
+//dump writes the values (see Ring.Dump).
+func (b *ring) dump(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for i := b.size - 1; i >= 0; i-- {
+		bw.WriteString(b.format(b.buf[b.index(i)]))
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+//goString formats the ring's internal state (see Ring.GoString).
+func (b *ring) goString() string {
+	return fmt.Sprintf("{head:%d, size:%d, capacity:%d, buf:%#v}", b.head, b.size, b.capacity, b.buf)
+}
+
+//String returns the ring's size, capacity and values from the oldest to the newest (see Ring.String).
+func (b *Unlocked) String() string { return b.string() }
+
+//SetFormatter sets the function formatting values in String and Dump (see Ring.SetFormatter).
+func (b *Unlocked) SetFormatter(format func(v interface{}) string) { b.formatter = format }
+
+//Dump writes the ring's values to 'w', one per line, from the oldest to the newest.
+func (b *Unlocked) Dump(w io.Writer) error { return b.dump(w) }
+
+//GoString returns the ring's full internal state.
+func (b *Unlocked) GoString() string { return "&ringbuffer.Unlocked" + b.goString() }
@@ -0,0 +1,52 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleRing_String() {
+	buf := New(5)
+	buf.Add(1, 2, 3)
+	fmt.Println(buf)
+	//Output: ring(3/5)[1 2 3]
+}
+
+func TestString(t *testing.T) {
+	b := New(20)
+	for i := 0; i < 20; i++ {
+		b.Add(i)
+	}
+	want := "ring(20/20)[0 1 2 3 4 5 6 7 ... 12 13 14 15 16 17 18 19]"
+	if b.String() != want {
+		t.Fatalf("Invalid string %q, expecting %q", b.String(), want)
+	}
+	b = New(2)
+	b.Add("a")
+	want = `&ringbuffer.Ring{head:0, size:1, capacity:2, buf:[]interface {}{"a", interface {}(nil)}}`
+	if fmt.Sprintf("%#v", b) != want {
+		t.Fatalf("Invalid go string %q, expecting %q", fmt.Sprintf("%#v", b), want)
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	b := New(5)
+	b.Add([]byte("hello"), []byte("secret"))
+	b.SetFormatter(func(v interface{}) string {
+		if string(v.([]byte)) == "secret" {
+			return "***"
+		}
+		return string(v.([]byte))
+	})
+	if b.String() != "ring(2/5)[hello ***]" {
+		t.Fatalf("Invalid string %q", b.String())
+	}
+	var dump strings.Builder
+	if err := b.Dump(&dump); err != nil {
+		t.Fatal(err.Error())
+	}
+	if dump.String() != "hello\n***\n" {
+		t.Fatalf("Invalid dump %q", dump.String())
+	}
+}
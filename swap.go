@@ -0,0 +1,58 @@
+package ringbuffer
+
+import "math/rand"
+
+//Swap exchanges the values at the indexes 'i' and 'j', interpreted as in Get, e.g. to reprioritize values.
+//
+// Their tags (see PushTagged) and their generations (see GetStamped) follow them:
+// handles and cursors keep designating the same values. It fails with ErrEmpty if the ring is empty.
+func (b *Ring) Swap(i, j int) error {
+	b.lock.Lock()
+	defer b.unlock()
+	return b.swap(i, j)
+}
+
+//swap exchanges the values at the indexes 'i' and 'j' (see Swap).
+func (b *ring) swap(i, j int) error {
+	if b.size == 0 {
+		return ErrEmpty
+	}
+	si, sj := b.position(i), b.position(j)
+	pi, pj := b.index(int(b.seq-si)), b.index(int(b.seq-sj))
+	b.buf[pi], b.buf[pj] = b.buf[pj], b.buf[pi]
+	b.exchange(si, sj)
+	if b.tags != nil {
+		ti, iok := b.tags[si]
+		tj, jok := b.tags[sj]
+		delete(b.tags, si)
+		delete(b.tags, sj)
+		if iok {
+			b.tags[sj] = ti
+		}
+		if jok {
+			b.tags[si] = tj
+		}
+	}
+	return nil
+}
+
+//Shuffle randomly permutes the ring's values, using 'rng' (or the math/rand default source if nil),
+//e.g. to replay a captured window in a random order.
+//
+// Like with Swap, tags, handles and cursors follow their values.
+func (b *Ring) Shuffle(rng *rand.Rand) {
+	b.lock.Lock()
+	defer b.unlock()
+	b.shuffle(rng)
+}
+
+//shuffle randomly permutes the ring's values (see Shuffle).
+func (b *ring) shuffle(rng *rand.Rand) {
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+	for i := b.size - 1; i > 0; i-- {
+		b.swap(i, intn(i+1))
+	}
+}
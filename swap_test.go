@@ -0,0 +1,111 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSwap(t *testing.T) {
+	b := New(4)
+	if err := b.Swap(0, 1); err != ErrEmpty {
+		t.Fatalf("Swap should fail on an empty ring, got %v", err)
+	}
+	b.Add(1, 2)
+	b.PushTagged(3, "high")
+	b.Add(4)
+	if err := b.Swap(0, -1); err != nil || fmt.Sprint(b.Values()) != "[4 2 3 1]" {
+		t.Fatalf("Invalid swap %v, %v", b.Values(), err)
+	}
+	b.Swap(1, 2)
+	if fmt.Sprint(b.Values()) != "[4 3 2 1]" || fmt.Sprint(b.ValuesTagged("high")) != "[3]" {
+		t.Fatalf("Invalid swap %v, tagged %v", b.Values(), b.ValuesTagged("high"))
+	}
+	if tags, _ := b.Tags(2); fmt.Sprint(tags) != "[high]" {
+		t.Fatalf("Tags should follow their value, got %v", tags)
+	}
+
+	u := NewUnlocked(3, WithOldestFirst())
+	u.Add(1, 2, 3)
+	u.Swap(0, 1)
+	if fmt.Sprint(u.Values()) != "[2 1 3]" {
+		t.Fatalf("Invalid swap %v", u.Values())
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	b := New(10)
+	b.Shuffle(nil) // empty, nothing to do
+	for i := 0; i < 10; i++ {
+		b.Add(i)
+	}
+	b.Shuffle(rand.New(rand.NewSource(1)))
+	shuffled := b.Values()
+	if fmt.Sprint(shuffled) == "[0 1 2 3 4 5 6 7 8 9]" {
+		t.Fatalf("The values should be shuffled")
+	}
+	slices.SortFunc(shuffled, func(x, y interface{}) int { return x.(int) - y.(int) })
+	if fmt.Sprint(shuffled) != "[0 1 2 3 4 5 6 7 8 9]" {
+		t.Fatalf("Shuffle should permute the values, got %v", b.Values())
+	}
+	u := NewUnlocked(10)
+	for i := 0; i < 10; i++ {
+		u.Add(i)
+	}
+	u.Shuffle(rand.New(rand.NewSource(1)))
+	if fmt.Sprint(u.Values()) != fmt.Sprint(b.Values()) {
+		t.Fatalf("The same source should give the same permutation, got %v and %v", u.Values(), b.Values())
+	}
+}
+
+func TestSwapReferences(t *testing.T) {
+	b := New(4)
+	h, _ := b.AddHandle("a")
+	b.Add("b")
+	_, gen, _ := b.GetStamped(0) // "b"
+	c, _ := b.Cursor(0)          // "b"
+	w := b.Window(0, 1)          // ["b"]
+	b.Swap(0, 1)
+	if v, ok := h.Get(); !ok || v != "a" {
+		t.Fatalf("The handle should follow its value, got %v, %v", v, ok)
+	}
+	if v, ok := c.Get(); !ok || v != "b" {
+		t.Fatalf("The cursor should follow its value, got %v, %v", v, ok)
+	}
+	if i, _ := c.Index(); i != 1 || c.Prev() {
+		t.Fatalf("The cursor should be on the oldest value, at %v", i)
+	}
+	if !c.Next() || c.Next() {
+		t.Fatalf("The cursor should move to the newest value, and no further")
+	}
+	if v, _ := c.Get(); v != "a" {
+		t.Fatalf("The cursor should be on %v, got %v", "a", v)
+	}
+	if fmt.Sprint(w.Values()) != "[b]" || w.Size() != 1 {
+		t.Fatalf("The window should follow its values, got %v", w.Values())
+	}
+	b.Add("c")
+	if v, ok := h.Get(); !ok || v != "a" || !b.StillValid(gen) {
+		t.Fatalf("The handle should still resolve, got %v, %v", v, ok)
+	}
+	b.Push("d", "e") // evicts "b", then "a"
+	if _, ok := h.Get(); ok || b.StillValid(gen) {
+		t.Fatalf("The references should not resolve once their values are evicted")
+	}
+	if fmt.Sprint(w.Values()) != "[]" || w.Size() != 0 {
+		t.Fatalf("The window should be empty, got %v", w.Values())
+	}
+
+	b = New(10)
+	handles := make([]Handle, 10)
+	for i := range handles {
+		handles[i], _ = b.AddHandle(i)
+	}
+	b.Shuffle(rand.New(rand.NewSource(1)))
+	for i, h := range handles {
+		if v, ok := h.Get(); !ok || v != i {
+			t.Fatalf("Handles should follow their shuffled value %v, got %v, %v", i, v, ok)
+		}
+	}
+}
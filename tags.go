@@ -0,0 +1,61 @@
+package ringbuffer
+
+import "slices"
+
+//PushTagged adds 'value' to the ring, discarding the oldest value if the ring is full,
+//with a set of tags, like "source=api".
+//
+// Tags let a single shared ring serve several logical streams, see ValuesTagged.
+func (b *Ring) PushTagged(value interface{}, tags ...string) {
+	b.lock.Lock()
+	defer b.unlock()
+	b.put(value)
+	b.pruneTags()
+	if len(tags) == 0 || !b.stillValid(b.seq) {
+		return
+	}
+	if b.tags == nil {
+		b.tags = make(map[uint64][]string)
+	}
+	b.tags[b.seq] = slices.Clone(tags)
+}
+
+//Tags returns the tags of the value at index 'i' (see Get).
+func (b *Ring) Tags(i int) ([]string, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if _, err := b.get(i); err != nil {
+		return nil, err
+	}
+	return slices.Clone(b.tags[b.position(i)]), nil
+}
+
+//ValuesTagged returns a copy of the ring's values having the tag 'tag', from the oldest to the newest.
+func (b *Ring) ValuesTagged(tag string) []interface{} {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	var values []interface{}
+	if len(b.tags) == 0 {
+		return values
+	}
+	for i := b.size - 1; i >= 0; i-- {
+		if slices.Contains(b.tags[b.seq-uint64(i)], tag) {
+			values = append(values, b.buf[b.index(i)])
+		}
+	}
+	return values
+}
+
+//pruneTags forgets the tags of the values that have left the ring.
+//
+// The tags are scanned once they outnumber the ring's values twice, amortizing the cost.
+func (b *ring) pruneTags() {
+	if len(b.tags) <= 2*b.size {
+		return
+	}
+	for seq := range b.tags {
+		if !b.stillValid(seq) {
+			delete(b.tags, seq)
+		}
+	}
+}
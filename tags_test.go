@@ -0,0 +1,40 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	b := New(3)
+	if b.ValuesTagged("source=api") != nil {
+		t.Fatalf("An empty ring has no tagged value")
+	}
+	b.Add(0)
+	b.PushTagged(1, "source=api")
+	b.Add(2)
+	b.PushTagged(3, "source=api", "level=error")
+	if fmt.Sprint(b.ValuesTagged("source=api")) != "[1 3]" {
+		t.Fatalf("Invalid tagged values %v", b.ValuesTagged("source=api"))
+	}
+	if tags, _ := b.Tags(0); fmt.Sprint(tags) != "[source=api level=error]" {
+		t.Fatalf("Invalid tags %v", tags)
+	}
+	if tags, _ := b.Tags(1); tags != nil {
+		t.Fatalf("Untagged values have no tags, got %v", tags)
+	}
+	b.Push(4, 5, 6)
+	if b.ValuesTagged("source=api") != nil {
+		t.Fatalf("Evicted values are not tagged, got %v", b.ValuesTagged("source=api"))
+	}
+	b.PushTagged(7, "source=db")
+	if fmt.Sprint(b.ValuesTagged("source=db")) != "[7]" {
+		t.Fatalf("Evicted values should lose their tags, got %v", b.ValuesTagged("source=api"))
+	}
+	for i := 0; i < 100; i++ {
+		b.PushTagged(i, "source=api")
+	}
+	if len(b.tags) > 2*b.Size()+1 {
+		t.Fatalf("Evicted values should lose their tags, %d tags left", len(b.tags))
+	}
+}
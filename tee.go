@@ -0,0 +1,28 @@
+package ringbuffer
+
+import "io"
+
+//Tee is an io.Writer forwarding all writes to another writer, while retaining the most recent bytes.
+type Tee struct {
+	w    io.Writer
+	ring *ByteRing
+}
+
+//TeeWriter returns a writer forwarding to 'w', and retaining the last 'capacity' bytes written.
+//
+// It is meant to dump "the last bytes we sent" when a downstream connection misbehaves.
+func TeeWriter(w io.Writer, capacity int) *Tee {
+	return &Tee{w: w, ring: NewByteRing(capacity)}
+}
+
+//Write forwards 'p', and retains the bytes actually written.
+func (t *Tee) Write(p []byte) (n int, err error) {
+	n, err = t.w.Write(p)
+	t.ring.Write(p[:n])
+	return n, err
+}
+
+//Bytes returns the last bytes written, from the oldest to the newest.
+func (t *Tee) Bytes() []byte {
+	return t.ring.Bytes()
+}
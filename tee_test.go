@@ -0,0 +1,21 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestTeeWriter(t *testing.T) {
+	var out bytes.Buffer
+	tee := TeeWriter(&out, 8)
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(tee, "line %d\n", i)
+	}
+	if out.Len() != 35 {
+		t.Fatalf("Invalid output %q", out.String())
+	}
+	if string(tee.Bytes()) != "\nline 4\n" {
+		t.Fatalf("Invalid retained bytes %q", tee.Bytes())
+	}
+}
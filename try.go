@@ -0,0 +1,50 @@
+package ringbuffer
+
+//TryPush pushes 'value' into the ring (see Push), without blocking.
+//
+// It returns the value discarded to make room for it, and false if nothing was discarded:
+// because the ring is empty, or currently locked by another goroutine (then 'value' is not pushed).
+//
+// In budget mode, where several values may be discarded, only the oldest is returned.
+func (b *Ring) TryPush(value interface{}) (evicted interface{}, ok bool) {
+	if !b.lock.TryLock() {
+		return nil, false
+	}
+	defer b.unlock()
+	return b.pushEvict(value)
+}
+
+//TryPop removes the oldest value from the ring and returns it, without blocking.
+//
+// It returns false if the ring is empty, or currently locked by another goroutine.
+func (b *Ring) TryPop() (value interface{}, ok bool) {
+	if !b.lock.TryLock() {
+		return nil, false
+	}
+	defer b.unlock()
+	return b.pop()
+}
+
+//pushEvict pushes 'value' and returns the discarded value, if any (see PushReturning).
+func (b *ring) pushEvict(value interface{}) (evicted interface{}, ok bool) {
+	if b.size > 0 {
+		evicted = b.buf[b.index(-1)]
+	}
+	size := b.size
+	b.push(value)
+	if b.sizeOf != nil && (b.size > size || b.sizeOf(value) > b.budget) {
+		// budget mode: there was room enough, or the value was discarded
+		return nil, false
+	}
+	return evicted, size > 0
+}
+
+//pop removes the oldest value and returns it (see TryPop).
+func (b *ring) pop() (value interface{}, ok bool) {
+	if b.size == 0 {
+		return nil, false
+	}
+	value = b.buf[b.index(-1)]
+	b.evict(1)
+	return value, true
+}
@@ -0,0 +1,57 @@
+package ringbuffer
+
+import "testing"
+
+func TestTryPush(t *testing.T) {
+	b := New(3)
+	if _, ok := b.TryPush(1); ok {
+		t.Fatalf("TryPush should not evict anything from an empty ring")
+	}
+	b.Add(1, 2)
+	if v, ok := b.TryPush(3); !ok || v != 1 {
+		t.Fatalf("TryPush should evict %v, got %v, %v", 1, v, ok)
+	}
+	b.lock.Lock()
+	if _, ok := b.TryPush(4); ok {
+		t.Fatalf("TryPush should not block")
+	}
+	b.lock.Unlock()
+
+	b = New(3, WithBudget(4, func(v interface{}) int { return v.(int) }))
+	if _, ok := b.TryPush(3); ok {
+		t.Fatalf("TryPush should not evict anything when there is room enough")
+	}
+	if v, ok := b.TryPush(2); !ok || v != 3 {
+		t.Fatalf("TryPush should evict %v, got %v, %v", 3, v, ok)
+	}
+	if _, ok := b.TryPush(5); ok {
+		t.Fatalf("TryPush should not evict anything for a discarded value")
+	}
+}
+
+func TestTryPop(t *testing.T) {
+	b := New(3)
+	if _, ok := b.TryPop(); ok {
+		t.Fatalf("TryPop should fail on an empty ring")
+	}
+	b.Add(1, 2)
+	if v, ok := b.TryPop(); !ok || v != 1 {
+		t.Fatalf("TryPop should return %v, got %v, %v", 1, v, ok)
+	}
+	if v, ok := b.TryPop(); !ok || v != 2 || b.Size() != 0 {
+		t.Fatalf("TryPop should return %v, got %v, %v", 2, v, ok)
+	}
+}
+
+func TestTryAllocs(t *testing.T) {
+	b := New(3)
+	b.Add(1, 2, 3)
+	allocs := testing.AllocsPerRun(100, func() {
+		v, _ := b.TryPop()
+		b.TryPush(v)
+		b.TryAdd(v)
+	})
+	if allocs != 0 {
+		t.Fatalf("Try functions should not allocate, got %v allocations", allocs)
+	}
+}
@@ -0,0 +1,142 @@
+package ringbuffer
+
+import "math/rand"
+
+//Unlocked is a ring buffer without any synchronization.
+//
+// It behaves exactly like Ring, but it is not safe for concurrent use: it is meant for single goroutine hot loops,
+// where locking costs more than the actual buffer work.
+type Unlocked struct {
+	ring
+}
+
+//NewUnlocked creates a new, empty, unsynchronized ring buffer.
+func NewUnlocked(capacity int, options ...Option) (b *Unlocked) {
+	b = &Unlocked{}
+	b.init(capacity, options)
+	return b
+}
+
+//Add values to the ring's head (see Ring.Add).
+func (b *Unlocked) Add(values ...interface{}) error { return b.add(values...) }
+
+//TryAdd adds 'value' to the ring's head, it returns false if the ring is full.
+func (b *Unlocked) TryAdd(value interface{}) bool { return b.addOne(value) == nil }
+
+//Remove 'count' values from the ring's tail (see Ring.Remove).
+func (b *Unlocked) Remove(count int) { b.remove(count) }
+
+//Push is equivalent to Remove then Add 'values' (see Ring.Push).
+func (b *Unlocked) Push(values ...interface{}) { b.push(values...) }
+
+//PushReturning pushes 'value' into the ring, and returns the value it discarded, if any (see Ring.PushReturning).
+func (b *Unlocked) PushReturning(value interface{}) (evicted interface{}, ok bool) {
+	return b.pushEvict(value)
+}
+
+//Get returns the value in the ring (see Ring.Get).
+func (b *Unlocked) Get(i int) (interface{}, error) { return b.get(i) }
+
+//GetStamped returns the value in the ring, and its generation (see Ring.GetStamped).
+func (b *Unlocked) GetStamped(i int) (v interface{}, gen uint64, err error) { return b.getStamped(i) }
+
+//StillValid tells whether the value of generation 'gen' is still in the ring (see Ring.StillValid).
+func (b *Unlocked) StillValid(gen uint64) bool { return b.stillLive(gen) }
+
+//GetOrDefault returns the value in the ring, or 'def' if the ring is empty.
+func (b *Unlocked) GetOrDefault(i int, def interface{}) interface{} {
+	v, err := b.get(i)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+//Newest returns the newest value in the ring (see Ring.Newest).
+func (b *Unlocked) Newest() (interface{}, error) { return b.get(b.logical(0)) }
+
+//Oldest returns the oldest value in the ring (see Ring.Oldest).
+func (b *Unlocked) Oldest() (interface{}, error) { return b.get(b.logical(-1)) }
+
+//MultiGet returns the values at several indexes (see Ring.MultiGet).
+func (b *Unlocked) MultiGet(indexes ...int) ([]interface{}, error) { return b.multiGet(indexes) }
+
+//GetRange returns the 'n' values at the consecutive indexes from 'i' (see Ring.GetRange).
+func (b *Unlocked) GetRange(i, n int) ([]interface{}, error) { return b.getRange(i, n) }
+
+//Slice returns the values from the index 'from' to 'to' excluded (see Ring.Slice).
+func (b *Unlocked) Slice(from, to int) ([]interface{}, error) { return b.slice(from, to) }
+
+//PushIfChanged adds 'value' unless it equals the newest value (see Ring.PushIfChanged).
+func (b *Unlocked) PushIfChanged(value interface{}, eq func(x, y interface{}) bool) bool {
+	return b.pushIfChanged(value, eq)
+}
+
+//PushUnique adds 'value' unless it equals a value in the ring (see Ring.PushUnique).
+func (b *Unlocked) PushUnique(value interface{}, eq func(x, y interface{}) bool) bool {
+	return b.pushUnique(value, eq, false)
+}
+
+//PushOrPromote adds 'value', or moves an equal value to the head (see Ring.PushOrPromote).
+func (b *Unlocked) PushOrPromote(value interface{}, eq func(x, y interface{}) bool) bool {
+	return b.pushUnique(value, eq, true)
+}
+
+//PushEvictFunc adds 'value', letting 'choose' decide which value to discard when the ring is full (see Ring.PushEvictFunc).
+func (b *Unlocked) PushEvictFunc(value interface{}, choose func(candidates []interface{}) int) {
+	b.pushEvictFunc(value, choose)
+}
+
+//Fill replaces the ring's values by 'value' repeated up to the ring's capacity (see Ring.Fill).
+func (b *Unlocked) Fill(value interface{}) { b.fill(value) }
+
+//Swap exchanges the values at the indexes 'i' and 'j' (see Ring.Swap).
+func (b *Unlocked) Swap(i, j int) error { return b.swap(i, j) }
+
+//Shuffle randomly permutes the ring's values (see Ring.Shuffle).
+func (b *Unlocked) Shuffle(rng *rand.Rand) { b.shuffle(rng) }
+
+//Values returns a copy of the ring's values, from the oldest to the newest.
+func (b *Unlocked) Values() []interface{} { return b.values() }
+
+//SetCapacity tries to set the ring's capacity (see Ring.SetCapacity).
+func (b *Unlocked) SetCapacity(capacity int) { b.setCapacity(capacity) }
+
+//Reset empties the ring and sets its capacity, at once (see Ring.Reset).
+func (b *Unlocked) Reset(capacity int) { b.reset(capacity) }
+
+//Capacity is the max size permitted
+func (b *Unlocked) Capacity() int { return b.capacity }
+
+//Size returns the ring's size.
+func (b *Unlocked) Size() int { return b.size }
+
+//Sequence returns the sequence number of the newest value (see Ring.Sequence).
+func (b *Unlocked) Sequence() uint64 { return b.seq }
+
+//IsEmpty returns true if the ring's size is zero.
+func (b *Unlocked) IsEmpty() bool { return b.size == 0 }
+
+//IsFull returns true if the ring's size has reached its capacity.
+func (b *Unlocked) IsFull() bool { return b.size >= b.capacity }
+
+//Free returns the remaining capacity, that is the number of values that can still be added.
+func (b *Unlocked) Free() int { return b.capacity - b.size }
+
+//Utilization returns the ring's fill level, from 0 (empty) to 1 (full).
+func (b *Unlocked) Utilization() float64 { return utilization(b.size, b.capacity) }
+
+//Budget returns the ring's memory budget in bytes, or 0 if it is not in budget mode.
+func (b *Unlocked) Budget() int { return b.budget }
+
+//Bytes returns the estimated size of the ring's values, or 0 if it is not in budget mode.
+func (b *Unlocked) Bytes() int { return b.bytes }
+
+//Dropped returns the number of values discarded by Push since the ring's creation.
+func (b *Unlocked) Dropped() uint64 { return b.stats.dropped.Load() }
+
+//TryPush pushes 'value' into the ring, and returns the discarded value, if any (see Ring.TryPush).
+func (b *Unlocked) TryPush(value interface{}) (evicted interface{}, ok bool) { return b.pushEvict(value) }
+
+//TryPop removes the oldest value from the ring and returns it, if any (see Ring.TryPop).
+func (b *Unlocked) TryPop() (value interface{}, ok bool) { return b.pop() }
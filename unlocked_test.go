@@ -0,0 +1,36 @@
+package ringbuffer
+
+import "testing"
+
+func TestUnlocked(t *testing.T) {
+	x := New(5)
+	b := NewUnlocked(5)
+	x.Add(1, 2, 3)
+	b.Add(1, 2, 3)
+	x.Push(4, 5, 6, 7)
+	b.Push(4, 5, 6, 7)
+	x.Remove(1)
+	b.Remove(1)
+	if b.Size() != x.Size() || b.Capacity() != x.Capacity() {
+		t.Fatalf("Invalid size/capacity %v/%v, expecting %v/%v", b.Size(), b.Capacity(), x.Size(), x.Capacity())
+	}
+	for i := 0; i < x.Size(); i++ {
+		v, _ := b.Get(i)
+		w, _ := x.Get(i)
+		if v != w {
+			t.Fatalf("Get(%v) = %v, expecting %v", i, v, w)
+		}
+	}
+}
+
+func BenchmarkPushUnlocked(b *testing.B) {
+	r := NewUnlocked(1000)
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Push(i)
+		r.Get(i)
+	}
+}
@@ -0,0 +1,194 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+)
+
+//WaitStrategy tells how the blocking methods (AddWait, PopWait) wait for the ring to change.
+//
+// Latency-sensitive consumers may prefer to burn a CPU spinning, while services prefer to park goroutines.
+type WaitStrategy interface {
+	//Wait blocks until 'ready' returns true, the ring is signaled, or 'ctx' is done (then it returns ctx.Err()).
+	//
+	// It may return early: callers check the ring again and wait again.
+	Wait(ctx context.Context, ready func() bool) error
+	//Signal is called after each modification of the ring, to wake up waiters.
+	Signal()
+}
+
+//WithWaitStrategy sets the ring's wait strategy, ParkWait() by default.
+//
+// It has no effect on Unlocked rings, which have no blocking methods.
+func WithWaitStrategy(s WaitStrategy) Option {
+	return func(b *ring) {
+		b.waiter = s
+	}
+}
+
+//SpinWait returns a strategy that busy-polls the ring: the lowest latency, at the cost of a whole CPU per waiter.
+func SpinWait() WaitStrategy {
+	return yieldWait(-1)
+}
+
+//YieldWait returns a strategy that busy-polls the ring 'spins' times, and then yields the processor between polls.
+func YieldWait(spins int) WaitStrategy {
+	return yieldWait(spins)
+}
+
+//ParkWait returns a strategy that parks waiters until the ring changes: the most CPU frugal one.
+func ParkWait() WaitStrategy {
+	return new(parkWait)
+}
+
+//yieldWait polls 'ready', yielding after as many spins (never if negative).
+type yieldWait int
+
+func (s yieldWait) Wait(ctx context.Context, ready func() bool) error {
+	for i := 0; !ready(); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if s >= 0 && i >= int(s) {
+			runtime.Gosched()
+		}
+	}
+	return nil
+}
+
+func (s yieldWait) Signal() {}
+
+//parkWait parks waiters on a channel, closed (and renewed) by Signal.
+type parkWait struct {
+	ch atomic.Pointer[chan struct{}]
+}
+
+func (s *parkWait) Wait(ctx context.Context, ready func() bool) error {
+	ch := s.channel()
+	if ready() { // checked after registering, so that no signal is missed
+		return nil
+	}
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//channel returns the channel closed by the next Signal.
+func (s *parkWait) channel() <-chan struct{} {
+	for {
+		if ch := s.ch.Load(); ch != nil {
+			return *ch
+		}
+		c := make(chan struct{})
+		if s.ch.CompareAndSwap(nil, &c) {
+			return c
+		}
+	}
+}
+
+func (s *parkWait) Signal() {
+	if s.ch.Load() == nil { // nobody is waiting
+		return
+	}
+	if ch := s.ch.Swap(nil); ch != nil {
+		close(*ch)
+	}
+}
+
+//AddWait adds 'value' to the Ring's head, waiting for room if it is full.
+//
+// It returns ctx.Err() if the context is done first.
+func (b *Ring) AddWait(ctx context.Context, value interface{}) error {
+	for {
+		b.lock.Lock()
+		err := b.addOne(value)
+		b.unlock()
+		if !errors.Is(err, ErrFull) {
+			return err
+		}
+		if err := b.waiter.Wait(ctx, b.hasRoom(value)); err != nil {
+			return err
+		}
+	}
+}
+
+//PopWait removes the oldest value from the ring and returns it, waiting for one if the ring is empty.
+//
+// It returns ctx.Err() if the context is done first.
+func (b *Ring) PopWait(ctx context.Context) (interface{}, error) {
+	for {
+		b.lock.Lock()
+		value, ok := b.pop()
+		b.unlock()
+		if ok {
+			return value, nil
+		}
+		if err := b.waiter.Wait(ctx, b.hasValues); err != nil {
+			return nil, err
+		}
+	}
+}
+
+//PeekAtLeast waits until the ring holds at least 'n' values, and returns them all without removing them, from the oldest to the newest.
+//
+// It fails with ErrRange if 'n' exceeds the ring's capacity, and returns ctx.Err() if the context is done first.
+func (b *Ring) PeekAtLeast(ctx context.Context, n int) ([]interface{}, error) {
+	enough := func() bool { return b.Size() >= n }
+	for {
+		b.lock.RLock()
+		if capacity := b.capacity; n > capacity {
+			b.lock.RUnlock()
+			return nil, fmt.Errorf("%w: %d values, capacity is %d", ErrRange, n, capacity)
+		}
+		if b.size >= n {
+			values := b.values()
+			b.lock.RUnlock()
+			return values, nil
+		}
+		b.lock.RUnlock()
+		if err := b.waiter.Wait(ctx, enough); err != nil {
+			return nil, err
+		}
+	}
+}
+
+//Flush waits until all the values present in the ring when it is called have been consumed (removed from the ring).
+//
+// Values pushed out of the ring count as consumed, as they can no longer be.
+// It returns ctx.Err() if the context is done first.
+func (b *Ring) Flush(ctx context.Context) error {
+	b.lock.RLock()
+	last := b.seq // sequence number of the newest value to consume
+	b.lock.RUnlock()
+	consumed := func() bool {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+		return b.seq-uint64(b.size) >= last
+	}
+	for !consumed() {
+		if err := b.waiter.Wait(ctx, consumed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//hasValues tells whether the ring is not empty.
+func (b *Ring) hasValues() bool {
+	return b.Size() > 0
+}
+
+//hasRoom returns a condition telling whether 'value' can be added.
+func (b *Ring) hasRoom(value interface{}) func() bool {
+	return func() bool {
+		b.lock.RLock()
+		defer b.lock.RUnlock()
+		return b.size+b.reserved < b.capacity && (b.sizeOf == nil || b.bytes+b.sizeOf(value) <= b.budget)
+	}
+}
@@ -0,0 +1,89 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWaitStrategies(t *testing.T) {
+	for name, s := range map[string]WaitStrategy{"spin": SpinWait(), "yield": YieldWait(10), "park": ParkWait()} {
+		b := New(2, WithWaitStrategy(s))
+		done := make(chan interface{})
+		go func() {
+			for i := 0; i < 100; i++ {
+				v, err := b.PopWait(context.Background())
+				if err != nil || v != i {
+					done <- err
+					return
+				}
+			}
+			done <- nil
+		}()
+		for i := 0; i < 100; i++ {
+			if err := b.AddWait(context.Background(), i); err != nil {
+				t.Fatalf("%s: AddWait failed: %v", name, err)
+			}
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("%s: PopWait failed: %v", name, err)
+		}
+	}
+}
+
+func TestWaitCanceled(t *testing.T) {
+	b := New(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := b.PopWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PopWait should time out, got %v", err)
+	}
+	b.Add(1)
+	if err := b.AddWait(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AddWait should time out, got %v", err)
+	}
+}
+
+func TestPeekAtLeast(t *testing.T) {
+	b := New(3)
+	b.Add(1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.Add(2, 3)
+	}()
+	values, err := b.PeekAtLeast(context.Background(), 2)
+	if err != nil || fmt.Sprint(values) != "[1 2 3]" {
+		t.Fatalf("PeekAtLeast should return %v, got %v, %v", "[1 2 3]", values, err)
+	}
+	if b.Size() != 3 {
+		t.Fatalf("PeekAtLeast should not remove values")
+	}
+	if _, err := b.PeekAtLeast(context.Background(), 4); !errors.Is(err, ErrRange) {
+		t.Fatalf("PeekAtLeast should fail beyond the capacity, got %v", err)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	b := New(3)
+	b.Add(1, 2)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		b.PopWait(context.Background())
+		b.Add(3) // added after Flush, not waited for
+		time.Sleep(10 * time.Millisecond)
+		b.PopWait(context.Background())
+	}()
+	if err := b.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if fmt.Sprint(b.Values()) != "[3]" {
+		t.Fatalf("Flush should return once 1 and 2 are consumed, got %v", b)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Flush should time out, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package ringbuffer
+
+import (
+	"context"
+	"slices"
+)
+
+//Watch evaluates 'agg' on the ring after its modifications, and calls 'f' when the result exceeds 'threshold'.
+//
+// 'f' is called when the aggregate crosses the threshold, and not again until it has gone back below.
+// Evaluations are debounced: they run in their own goroutine, and modifications made during an evaluation
+// only trigger one more. 'agg' and 'f' can then use the ring, e.g. "alert when the error rate exceeds 5%".
+//
+// It returns a function stopping the watch, and waiting for an evaluation in progress: 'agg' and 'f' must not call it.
+func (b *Ring) Watch(agg func(*Ring) float64, threshold float64, f func(value float64)) (stop func()) {
+	notify, runner := make(chan struct{}, 1), new(Runner)
+	b.lock.Lock()
+	b.watches = append(b.watches, notify)
+	b.lock.Unlock()
+	runner.Start(func(stop <-chan struct{}) {
+		above := false
+		for {
+			select {
+			case <-notify:
+			case <-stop:
+				return
+			}
+			value := agg(b)
+			if value > threshold && !above {
+				f(value)
+			}
+			above = value > threshold
+		}
+	})
+	return func() {
+		b.lock.Lock()
+		b.watches = slices.DeleteFunc(b.watches, func(w chan struct{}) bool { return w == notify })
+		b.lock.Unlock()
+		runner.Stop()
+		runner.Wait(context.Background())
+	}
+}
@@ -0,0 +1,48 @@
+package ringbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	b := New(4)
+	errorRate := func(b *Ring) float64 {
+		errors := 0
+		b.ForEach(func(v interface{}) {
+			if v == "error" {
+				errors++
+			}
+		})
+		return float64(errors) / float64(b.Capacity())
+	}
+	alerts := make(chan float64, 10)
+	stop := b.Watch(errorRate, 0.4, func(value float64) { alerts <- value })
+
+	wait := func() (float64, bool) {
+		select {
+		case v := <-alerts:
+			return v, true
+		case <-time.After(50 * time.Millisecond):
+			return 0, false
+		}
+	}
+	b.Add("ok", "error")
+	if v, ok := wait(); ok {
+		t.Fatalf("Watch should not alert under the threshold, got %v", v)
+	}
+	b.Add("error")
+	if v, ok := wait(); !ok || v != 0.5 {
+		t.Fatalf("Watch should alert over the threshold, got %v, %v", v, ok)
+	}
+	b.Add("error")
+	if v, ok := wait(); ok {
+		t.Fatalf("Watch should not alert again while over the threshold, got %v", v)
+	}
+	stop()
+	b.Remove(4)
+	b.Add("error", "error")
+	if v, ok := wait(); ok {
+		t.Fatalf("Watch should not alert once stopped, got %v", v)
+	}
+}
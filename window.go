@@ -0,0 +1,135 @@
+package ringbuffer
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+//Window is a view over a range of a ring's values, pinned to their generations (see GetStamped):
+// it keeps designating the same values while new ones are pushed, or the ring is reordered.
+type Window struct {
+	ring        *Ring
+	first, last uint64 // generations of the oldest and newest values
+}
+
+//Window returns a view over the 'length' values [Get(offset+length-1), ..., Get(offset)].
+//
+// A negative 'offset' reaches values that are not pushed yet: Window(-50, 100) designates the 50 values
+// before the newest (included), and the 50 next ones, as they are pushed.
+// The window is truncated to the values pushed since the ring's creation: it is empty if 'length' is not positive,
+// or if it ends before the first value ever pushed.
+//
+// With WithOldestFirst, the window is [Get(offset), ..., Get(offset+length-1)], counted from the oldest value.
+// Once the ring has been reordered (see Swap), the window designates the values added in the same range instead.
+func (b *Ring) Window(offset, length int) *Window {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	// in signed arithmetic, for the sequence numbers before the first one (1)
+	last := int64(b.seq) - int64(offset)
+	first := last - int64(length) + 1
+	if b.oldestFirst {
+		first = int64(b.seq) - int64(b.size) + 1 + int64(offset)
+		last = first + int64(length) - 1
+	}
+	first = max(first, 1)
+	if length <= 0 || last < first {
+		return &Window{ring: b, first: 1, last: 0}
+	}
+	return &Window{ring: b, first: uint64(first), last: uint64(last)}
+}
+
+//Sequences returns the generations of the window's oldest and newest values.
+func (w *Window) Sequences() (first, last uint64) {
+	return w.first, w.last
+}
+
+//len returns the number of values in the window, in the ring or not.
+func (w *Window) len() uint64 {
+	return w.last + 1 - w.first
+}
+
+//Get returns the window's value at index 'i', 0 being the newest, or the oldest with WithOldestFirst (see Ring.Get).
+//
+// It fails with ErrRange if 'i' is out of the window, or the value is not in the ring (anymore, or yet).
+func (w *Window) Get(i int) (interface{}, error) {
+	if i < 0 || uint64(i) >= w.len() {
+		return nil, fmt.Errorf("%w: index %d out of a window of %d values", ErrRange, i, w.len())
+	}
+	b := w.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	gen := w.last - uint64(i)
+	if b.oldestFirst {
+		gen = w.first + uint64(i)
+	}
+	seq, ok := b.resolve(gen)
+	if !ok {
+		return nil, fmt.Errorf("%w: value %d is not in the ring", ErrRange, gen)
+	}
+	return b.buf[b.index(int(b.seq-seq))], nil
+}
+
+//Size returns the number of the window's values in the ring.
+func (w *Window) Size() int {
+	b := w.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if b.generations != nil {
+		return len(w.moved())
+	}
+	first, last := w.present()
+	return int(last - first + 1)
+}
+
+//Values returns a copy of the window's values in the ring, from the oldest to the newest.
+func (w *Window) Values() []interface{} {
+	b := w.ring
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if b.generations != nil {
+		return w.moved()
+	}
+	first, last := w.present()
+	values, _ := b.chunk(first, int(last-first+1))
+	return values
+}
+
+//moved returns the window's values in a reordered ring (see Swap), from the oldest generation to the newest.
+func (w *Window) moved() []interface{} {
+	b := w.ring
+	gens := make([]uint64, 0, b.size)
+	for seq := b.seq - uint64(b.size) + 1; seq <= b.seq && b.size > 0; seq++ {
+		if gen := b.generation(seq); gen >= w.first && gen <= w.last {
+			gens = append(gens, gen)
+		}
+	}
+	slices.Sort(gens)
+	values := make([]interface{}, len(gens))
+	for i, gen := range gens {
+		seq, _ := b.resolve(gen)
+		values[i] = b.buf[b.index(int(b.seq-seq))]
+	}
+	return values
+}
+
+//All returns an iterator over the window's values in the ring, from the oldest to the newest.
+func (w *Window) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range w.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+//present returns the range of the window's sequence numbers that are in the ring (last < first if there are none).
+func (w *Window) present() (first, last uint64) {
+	b := w.ring
+	first, last = max(w.first, b.seq-uint64(b.size)+1), min(w.last, b.seq)
+	if last < first {
+		return 1, 0
+	}
+	return first, last
+}
@@ -0,0 +1,79 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	b := New(5)
+	b.Add(1, 2, 3, 4)
+	w := b.Window(1, 2) // [2 3]
+	if fmt.Sprint(w.Values()) != "[2 3]" || w.Size() != 2 {
+		t.Fatalf("Invalid window %v", w.Values())
+	}
+	b.Add(5)
+	if v, _ := w.Get(0); v != 3 {
+		t.Fatalf("Window should be pinned to sequence numbers, got %v", v)
+	}
+	b.Push(6, 7) // evicts 1 and 2
+	if _, err := w.Get(1); !errors.Is(err, ErrRange) {
+		t.Fatalf("Get should fail for evicted values, got %v", err)
+	}
+	if fmt.Sprint(w.Values()) != "[3]" || w.Size() != 1 {
+		t.Fatalf("Invalid window %v", w.Values())
+	}
+
+	w = b.Window(-1, 3) // [6 7 8], 8 not pushed yet
+	var values []interface{}
+	for v := range w.All() {
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[6 7]" {
+		t.Fatalf("Invalid window %v", values)
+	}
+	b.Push(8)
+	if v, _ := w.Get(0); v != 8 || w.Size() != 3 {
+		t.Fatalf("Window should include future values, got %v", v)
+	}
+	if _, err := w.Get(3); !errors.Is(err, ErrRange) {
+		t.Fatalf("Get should fail out of the window, got %v", err)
+	}
+	if first, last := New(3).Window(0, 3).Sequences(); first != 1 || last != 0 {
+		t.Fatalf("Window should be truncated to the ring's creation, got %v, %v", first, last)
+	}
+
+	b = New(5)
+	b.Add(1, 2, 3)
+	for _, c := range []struct {
+		offset, length int
+		oldestFirst    bool
+		first, last    uint64
+	}{
+		{0, 0, false, 1, 0},
+		{0, -2, false, 1, 0},
+		{3, 2, false, 1, 0},   // before the first value
+		{100, 2, false, 1, 0}, // far before the first value
+		{2, 3, false, 1, 1},   // truncated to the first value
+		{-2, 2, false, 4, 5},
+		{-2, 3, true, 1, 1}, // truncated to the first value
+		{-5, 3, true, 1, 0}, // before the first value
+		{0, -1, true, 1, 0},
+	} {
+		b := b
+		if c.oldestFirst {
+			b = New(5, WithOldestFirst())
+			b.Add(1, 2, 3)
+		}
+		w := b.Window(c.offset, c.length)
+		if first, last := w.Sequences(); first != c.first || last != c.last {
+			t.Fatalf("Window(%v, %v) should be [%v, %v], got [%v, %v]", c.offset, c.length, c.first, c.last, first, last)
+		}
+		if c.last < c.first {
+			if _, err := w.Get(0); !errors.Is(err, ErrRange) || w.Size() != 0 || len(w.Values()) != 0 {
+				t.Fatalf("Window(%v, %v) should be empty, got %v, %v", c.offset, c.length, w.Values(), err)
+			}
+		}
+	}
+}
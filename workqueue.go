@@ -0,0 +1,100 @@
+package ringbuffer
+
+import (
+	"context"
+	"sync"
+)
+
+//FullPolicy tells what a WorkQueue does with a task submitted while the queue is full.
+type FullPolicy int
+
+const (
+	//FullReject makes Submit fail with ErrFull.
+	FullReject FullPolicy = iota
+	//FullDropOldest discards the oldest pending task to make room for the new one.
+	FullDropOldest
+	//FullBlock makes Submit wait until there is room.
+	FullBlock
+)
+
+//WorkQueue is a bounded queue of tasks, drained by a pool of workers.
+type WorkQueue struct {
+	lock      sync.Mutex
+	available *sync.Cond // signaled when a task is submitted, or the queue is shut down
+	room      *sync.Cond // signaled when a task is taken, or the queue is shut down
+	ring      *Unlocked
+	policy    FullPolicy
+	closed    bool
+	workers   Runner
+}
+
+//NewWorkQueue creates a queue of 'capacity' pending tasks, and starts 'workers' goroutines running them.
+func NewWorkQueue(capacity, workers int, policy FullPolicy) *WorkQueue {
+	q := &WorkQueue{ring: NewUnlocked(capacity), policy: policy}
+	q.available = sync.NewCond(&q.lock)
+	q.room = sync.NewCond(&q.lock)
+	for i := 0; i < workers; i++ {
+		q.workers.Start(q.work)
+	}
+	return q
+}
+
+//Submit queues 'task' to be run by a worker.
+//
+// When the queue is full, the policy applies. It fails with ErrClosed once the queue is shut down.
+func (q *WorkQueue) Submit(task func()) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for q.policy == FullBlock && !q.closed && q.ring.size == q.ring.capacity {
+		q.room.Wait()
+	}
+	if q.closed {
+		return ErrClosed
+	}
+	switch {
+	case q.policy == FullDropOldest:
+		q.ring.put(task)
+	default:
+		if err := q.ring.add(task); err != nil {
+			return err
+		}
+	}
+	q.available.Signal()
+	return nil
+}
+
+//Pending returns the number of tasks waiting for a worker.
+func (q *WorkQueue) Pending() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.ring.size
+}
+
+//Shutdown stops accepting tasks, and waits until the pending ones are run, or 'ctx' is done.
+func (q *WorkQueue) Shutdown(ctx context.Context) error {
+	q.lock.Lock()
+	q.closed = true
+	q.available.Broadcast()
+	q.room.Broadcast()
+	q.lock.Unlock()
+	q.workers.Stop()
+
+	return q.workers.Wait(ctx)
+}
+
+//work runs tasks until the queue is shut down and drained.
+func (q *WorkQueue) work(<-chan struct{}) {
+	for {
+		q.lock.Lock()
+		for q.ring.size == 0 && !q.closed {
+			q.available.Wait()
+		}
+		task, ok := q.ring.pop()
+		q.room.Signal()
+		q.lock.Unlock()
+		if !ok { // closed and drained
+			return
+		}
+		task.(func())()
+	}
+}
@@ -0,0 +1,66 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkQueue(t *testing.T) {
+	var done atomic.Int64
+	q := NewWorkQueue(10, 3, FullBlock)
+	for i := 0; i < 100; i++ {
+		if err := q.Submit(func() { done.Add(1) }); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	}
+	if done.Load() != 100 {
+		t.Fatalf("Invalid tasks run %v, expecting %v", done.Load(), 100)
+	}
+	if err := q.Submit(func() {}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("should have failed with ErrClosed, got %v", err)
+	}
+}
+
+func TestWorkQueuePolicies(t *testing.T) {
+	block := make(chan struct{})
+	var done atomic.Int64
+	q := NewWorkQueue(1, 1, FullReject)
+	q.Submit(func() { <-block })
+	for q.Pending() > 0 { // wait for the worker to take it
+		time.Sleep(time.Millisecond)
+	}
+	q.Submit(func() { done.Add(1) })
+	if err := q.Submit(func() {}); !errors.Is(err, ErrFull) {
+		t.Fatalf("should have failed with ErrFull, got %v", err)
+	}
+	close(block)
+	q.Shutdown(context.Background())
+	if done.Load() != 1 {
+		t.Fatalf("Invalid tasks run %v, expecting %v", done.Load(), 1)
+	}
+
+	block = make(chan struct{})
+	q = NewWorkQueue(1, 1, FullDropOldest)
+	q.Submit(func() { <-block })
+	for q.Pending() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	q.Submit(func() { done.Add(10) })
+	q.Submit(func() { done.Add(100) })
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := q.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("should have timed out, got %v", err)
+	}
+	close(block)
+	q.Shutdown(context.Background())
+	if done.Load() != 101 {
+		t.Fatalf("Invalid tasks run %v, expecting %v", done.Load(), 101)
+	}
+}